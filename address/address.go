@@ -0,0 +1,194 @@
+// Package address parses and formats glitter account addresses in their bech32 glitter1...,
+// EIP-55 checksummed 0x... and raw byte forms
+package address
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+)
+
+// Length byte length of an address
+const Length = 20
+
+// bech32 prefixes of glitter addresses
+const (
+	AccountPrefix   = msg.GlitterAccountPrefix
+	ValidatorPrefix = AccountPrefix + "valoper"
+	ConsensusPrefix = AccountPrefix + "valcons"
+)
+
+// Address glitter account address, the zero Address is empty
+type Address [Length]byte
+
+// Parse parse the glitter1... or 0x... form of an address
+func Parse(s string) (Address, error) {
+	return ParseWithPrefix(s, AccountPrefix)
+}
+
+// MustParse parse the address s, panic if it is invalid
+func MustParse(s string) Address {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// ParseWithPrefix parse the 0x... form or the bech32 form of accountPrefix of an address,
+// a mixed case 0x... address must match its EIP-55 checksum
+func ParseWithPrefix(s, accountPrefix string) (Address, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return Address{}, fmt.Errorf("empty address")
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return parseHex(s)
+	}
+	return ParseBech32(s, accountPrefix)
+}
+
+// ParseBech32 parse the bech32 form of an address, its prefix must be prefix
+func ParseBech32(s, prefix string) (Address, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid bech32 address %q: %w", s, err)
+	}
+	if hrp != prefix {
+		return Address{}, fmt.Errorf("invalid bech32 address %q: expected prefix %s, got %s", s, prefix, hrp)
+	}
+	return FromBytes(bz)
+}
+
+func parseHex(s string) (Address, error) {
+	digits := s[2:]
+	if len(digits) != 2*Length {
+		return Address{}, fmt.Errorf("invalid hex address %q: expected %d hex digits, got %d", s, 2*Length, len(digits))
+	}
+	bz, err := hex.DecodeString(digits)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid hex address %q: %w", s, err)
+	}
+	a, _ := FromBytes(bz)
+	// an all lower or all upper case address carries no checksum
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && "0x"+digits != a.Hex() {
+		return Address{}, fmt.Errorf("invalid hex address %q: EIP-55 checksum mismatch, expected %s", s, a.Hex())
+	}
+	return a, nil
+}
+
+// FromBytes returns the address of the raw bytes bz
+func FromBytes(bz []byte) (Address, error) {
+	var a Address
+	if len(bz) != Length {
+		return a, fmt.Errorf("invalid address length %d, expected %d", len(bz), Length)
+	}
+	copy(a[:], bz)
+	return a, nil
+}
+
+// FromAccAddress returns the address of addr
+func FromAccAddress(addr msg.AccAddress) (Address, error) {
+	return FromBytes(addr)
+}
+
+// Empty returns whether a is the zero address
+func (a Address) Empty() bool {
+	return a == Address{}
+}
+
+// Bytes returns the raw bytes of a
+func (a Address) Bytes() []byte {
+	return a[:]
+}
+
+// AccAddress returns a as msg.AccAddress
+func (a Address) AccAddress() msg.AccAddress {
+	return msg.AccAddress(a.Bytes())
+}
+
+// String returns the glitter1... form of a
+func (a Address) String() string {
+	return a.Bech32(AccountPrefix)
+}
+
+// Bech32 returns the bech32 form of a with prefix
+func (a Address) Bech32(prefix string) string {
+	s, err := bech32.ConvertAndEncode(prefix, a.Bytes())
+	if err != nil {
+		// only fails on an invalid prefix
+		panic(err)
+	}
+	return s
+}
+
+// Hex returns the EIP-55 checksummed 0x... form of a
+func (a Address) Hex() string {
+	return common.Address(a).Hex()
+}
+
+// ValAddress returns the glittervaloper... form of a
+func (a Address) ValAddress() string {
+	return a.Bech32(ValidatorPrefix)
+}
+
+// ConsAddress returns the glittervalcons... form of a
+func (a Address) ConsAddress() string {
+	return a.Bech32(ConsensusPrefix)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the glitter1... and 0x... forms
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the address is encoded as its glitter1... form
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the glitter1... and 0x... forms
+func (a *Address) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	return a.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, accepting the glitter1... and 0x... strings and the raw bytes
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == Length {
+			copy(a[:], v)
+			return nil
+		}
+		return a.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into address", src)
+	}
+}
+
+// Value implements driver.Valuer, the address is stored as its glitter1... form
+func (a Address) Value() (driver.Value, error) {
+	return a.String(), nil
+}
@@ -0,0 +1,83 @@
+package address
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBech32 = "glitter1q5t6prazp4wlzegvaz5sls25phyvvmq6aqpxf7"
+	testHex    = "0x0517a08fa20d5Df1650cE8a90Fc1540dc8c66c1A"
+)
+
+func Test_Parse(t *testing.T) {
+	a, err := Parse(testBech32)
+	require.NoError(t, err)
+	assert.Equal(t, testBech32, a.String())
+	assert.Equal(t, testHex, a.Hex())
+	assert.True(t, strings.HasPrefix(a.ValAddress(), "glittervaloper1"))
+	assert.True(t, strings.HasPrefix(a.ConsAddress(), "glittervalcons1"))
+
+	for _, s := range []string{testHex, strings.ToLower(testHex), "0x" + strings.ToUpper(testHex[2:]), " " + testBech32} {
+		b, err := Parse(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, a, b, s)
+	}
+
+	b, err := FromBytes(a.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	for _, s := range []string{
+		"",
+		"0x0517a08fa20d5Df1650cE8a90Fc1540dc8c66c1a", // bad checksum
+		"0x0517a08fa20d5df1650ce8a90fc1540dc8c66c",   // short
+		"0x0517a08fa20d5df1650ce8a90fc1540dc8c66czz", // not hex
+		a.Bech32("cosmos"),
+		a.ValAddress(),
+		"glitter1q5t6prazp4wlzegvaz5sls25phyvvmq6aqpxf8", // bad bech32 checksum
+	} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+	_, err = FromBytes(make([]byte, 32))
+	assert.Error(t, err)
+
+	c, err := ParseWithPrefix(a.Bech32("cosmos"), "cosmos")
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+}
+
+func Test_AddressEncoding(t *testing.T) {
+	a := MustParse(testHex)
+
+	bz, err := json.Marshal(struct {
+		UID Address `json:"uid"`
+	}{a})
+	require.NoError(t, err)
+	assert.Equal(t, `{"uid":"`+testBech32+`"}`, string(bz))
+
+	var decoded struct {
+		UID Address `json:"uid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"uid":"`+testHex+`"}`), &decoded))
+	assert.Equal(t, a, decoded.UID)
+	assert.Error(t, json.Unmarshal([]byte(`{"uid":"nope"}`), &decoded))
+
+	v, err := a.Value()
+	require.NoError(t, err)
+	assert.Equal(t, testBech32, v)
+
+	var scanned Address
+	for _, src := range []interface{}{testBech32, []byte(testHex), a.Bytes()} {
+		scanned = Address{}
+		require.NoError(t, scanned.Scan(src))
+		assert.Equal(t, a, scanned)
+	}
+	assert.Error(t, scanned.Scan(int64(1)))
+	assert.True(t, Address{}.Empty())
+}
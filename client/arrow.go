@@ -0,0 +1,153 @@
+//go:build arrow
+// +build arrow
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// The Arrow output is built with the arrow build tag so programs not using it do not link Arrow,
+// build and test it with make test-arrow or:
+//
+//	go test -tags arrow ./client/...
+
+// ColumnTypeMetadataKey field metadata key holding the sql type of the column
+const ColumnTypeMetadataKey = "glitter.column_type"
+
+// QueryArrow run a query and returns every result set as an Arrow record, the caller must
+// Release the records
+// Args:
+//   - sql: The SQL query string
+//   - args: Optional list of arguments to substitute into the query
+//
+// Returns:
+// A record per result set with the schema of ArrowSchema, INT, UINT, FLOAT and BOOL values are
+// parsed to typed arrays with empty values as nulls, BYTES values are base64 decoded
+func (lcd *LCDClient) QueryArrow(ctx context.Context, sql string, args ...*glittertypes.Argument) ([]arrow.Record, error) {
+	res, err := lcd.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]arrow.Record, 0, len(res.Results))
+	for _, rs := range res.Results {
+		record, err := NewArrowRecord(memory.DefaultAllocator, rs)
+		if err != nil {
+			for _, r := range records {
+				r.Release()
+			}
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ArrowSchema returns the Arrow schema of result columns, all fields are nullable
+func ArrowSchema(columns []*glittertypes.ColumnDef) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		fields[i] = arrow.Field{
+			Name:     c.ColumnName,
+			Type:     arrowType(c.ColumnValueType),
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{ColumnTypeMetadataKey}, []string{c.ColumnType}),
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// NewArrowRecord convert a result set to an Arrow record allocated from mem
+func NewArrowRecord(mem memory.Allocator, rs *glittertypes.ResultSet) (arrow.Record, error) {
+	b := array.NewRecordBuilder(mem, ArrowSchema(rs.ColumnDefs))
+	defer b.Release()
+	b.Reserve(len(rs.Rows))
+
+	for i, c := range rs.ColumnDefs {
+		if err := appendArrowColumn(b.Field(i), c, i, rs.Rows); err != nil {
+			return nil, fmt.Errorf("column %s: %w", c.ColumnName, err)
+		}
+	}
+	return b.NewRecord(), nil
+}
+
+func arrowType(valueType glittertypes.ColumnValueType) arrow.DataType {
+	switch valueType {
+	case glittertypes.ColumnValueType_IntColumn:
+		return arrow.PrimitiveTypes.Int64
+	case glittertypes.ColumnValueType_UintColumn:
+		return arrow.PrimitiveTypes.Uint64
+	case glittertypes.ColumnValueType_FloatColumn:
+		return arrow.PrimitiveTypes.Float64
+	case glittertypes.ColumnValueType_BoolColumn:
+		return arrow.FixedWidthTypes.Boolean
+	case glittertypes.ColumnValueType_BytesColumn:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendArrowColumn parse the values of column i of rows into the typed builder of the column
+func appendArrowColumn(builder array.Builder, column *glittertypes.ColumnDef, i int, rows []*glittertypes.RowData) error {
+	for n, row := range rows {
+		if i >= len(row.Columns) {
+			return fmt.Errorf("row %d has %d values", n, len(row.Columns))
+		}
+		s := row.Columns[i]
+		if len(s) == 0 && column.ColumnValueType != glittertypes.ColumnValueType_StringColumn &&
+			column.ColumnValueType != glittertypes.ColumnValueType_BytesColumn {
+			builder.AppendNull()
+			continue
+		}
+
+		var err error
+		switch b := builder.(type) {
+		case *array.Int64Builder:
+			var v int64
+			if v, err = strconv.ParseInt(s, 10, 64); err == nil {
+				b.Append(v)
+			}
+		case *array.Uint64Builder:
+			var v uint64
+			if v, err = strconv.ParseUint(s, 10, 64); err == nil {
+				b.Append(v)
+			}
+		case *array.Float64Builder:
+			var v float64
+			if v, err = strconv.ParseFloat(s, 64); err == nil {
+				b.Append(v)
+			}
+		case *array.BooleanBuilder:
+			var v bool
+			if v, err = strconv.ParseBool(s); err == nil {
+				b.Append(v)
+			}
+		case *array.BinaryBuilder:
+			if column.ColumnValueType == glittertypes.ColumnValueType_BytesColumn {
+				var v []byte
+				if v, err = base64.StdEncoding.DecodeString(s); err == nil {
+					b.Append(v)
+				}
+			} else {
+				b.AppendString(s)
+			}
+		case *array.StringBuilder:
+			b.Append(s)
+		default:
+			return fmt.Errorf("unsupported builder %T", builder)
+		}
+		if err != nil {
+			return fmt.Errorf("row %d: %w", n, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build arrow
+// +build arrow
+
+package client
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewArrowRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rs := &glittertypes.ResultSet{
+		ColumnDefs: []*glittertypes.ColumnDef{
+			{ColumnName: "_id", ColumnType: "INT", ColumnValueType: glittertypes.ColumnValueType_IntColumn},
+			{ColumnName: "title", ColumnType: "VARCHAR", ColumnValueType: glittertypes.ColumnValueType_StringColumn},
+			{ColumnName: "size", ColumnType: "INT", ColumnValueType: glittertypes.ColumnValueType_UintColumn},
+			{ColumnName: "rating", ColumnType: "DOUBLE", ColumnValueType: glittertypes.ColumnValueType_FloatColumn},
+			{ColumnName: "free", ColumnType: "BOOLEAN", ColumnValueType: glittertypes.ColumnValueType_BoolColumn},
+			{ColumnName: "cover", ColumnType: "BLOB", ColumnValueType: glittertypes.ColumnValueType_BytesColumn},
+		},
+		Rows: []*glittertypes.RowData{
+			{Columns: []string{"1", "Dune", "1024", "4.5", "true", "aGk="}},
+			{Columns: []string{"-2", "Emma", "", "3", "", ""}},
+		},
+	}
+	record, err := NewArrowRecord(mem, rs)
+	assert.NoError(t, err)
+	defer record.Release()
+
+	assert.Equal(t, int64(2), record.NumRows())
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, record.Schema().Field(0).Type)
+	assert.Equal(t, arrow.BinaryTypes.Binary, record.Schema().Field(5).Type)
+	assert.Equal(t, []int64{1, -2}, record.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, "Emma", record.Column(1).(*array.String).Value(1))
+	assert.True(t, record.Column(2).IsNull(1))
+	assert.Equal(t, 4.5, record.Column(3).(*array.Float64).Value(0))
+	assert.True(t, record.Column(4).(*array.Boolean).Value(0))
+	assert.True(t, record.Column(4).IsNull(1))
+	assert.Equal(t, []byte("hi"), record.Column(5).(*array.Binary).Value(0))
+	assert.Empty(t, record.Column(5).(*array.Binary).Value(1))
+
+	rs.Rows = append(rs.Rows, &glittertypes.RowData{Columns: []string{"x", "", "", "", "", ""}})
+	_, err = NewArrowRecord(mem, rs)
+	assert.EqualError(t, err, `column _id: row 2: strconv.ParseInt: parsing "x": invalid syntax`)
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// GrantAuthorization Authorize grantee to execute messages on behalf of the client account
+// Args:
+//   - grantee: Address allowed to execute messages
+//   - authorization: Authorization to grant, such as authz.NewGenericAuthorization(msgTypeURL)
+//   - expiration: Time the grant expires
+//
+// Returns:
+// Result of broadcasting grant transaction
+func (lcd *LCDClient) GrantAuthorization(ctx context.Context, grantee msg.AccAddress, authorization authz.Authorization, expiration time.Time) (*sdk.TxResponse, error) {
+	_msg, err := lcd.newGrantMsg(grantee, authorization, expiration)
+	if err != nil {
+		return nil, err
+	}
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
+
+func (lcd *LCDClient) newGrantMsg(grantee msg.AccAddress, authorization authz.Authorization, expiration time.Time) (*authz.MsgGrant, error) {
+	_msg := &authz.MsgGrant{Granter: lcd.Bech32Address(), Grantee: lcd.bech32(grantee), Grant: authz.Grant{Expiration: expiration}}
+	if err := _msg.SetAuthorization(authorization); err != nil {
+		return nil, err
+	}
+	return _msg, nil
+}
+
+// GrantSQLExec Authorize grantee to execute SQL on behalf of the client account
+// Args:
+//   - grantee: Address allowed to execute SQL
+//   - expiration: Time the grant expires
+//
+// Returns:
+// Result of broadcasting grant transaction
+func (lcd *LCDClient) GrantSQLExec(ctx context.Context, grantee msg.AccAddress, expiration time.Time) (*sdk.TxResponse, error) {
+	return lcd.GrantAuthorization(ctx, grantee, authz.NewGenericAuthorization(SQLExecMsgTypeURL), expiration)
+}
+
+// RevokeAuthorization Revoke the authorization of grantee to execute msgTypeURL messages
+// Args:
+//   - grantee: Address of the authorization grantee
+//   - msgTypeURL: Type url of the authorized message
+//
+// Returns:
+// Result of broadcasting revoke transaction
+func (lcd *LCDClient) RevokeAuthorization(ctx context.Context, grantee msg.AccAddress, msgTypeURL string) (*sdk.TxResponse, error) {
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{lcd.newRevokeMsg(grantee, msgTypeURL)}})
+}
+
+func (lcd *LCDClient) newRevokeMsg(grantee msg.AccAddress, msgTypeURL string) *authz.MsgRevoke {
+	return &authz.MsgRevoke{Granter: lcd.Bech32Address(), Grantee: lcd.bech32(grantee), MsgTypeUrl: msgTypeURL}
+}
+
+// RevokeSQLExec Revoke the authorization of grantee to execute SQL
+// Args:
+//   - grantee: Address of the authorization grantee
+//
+// Returns:
+// Result of broadcasting revoke transaction
+func (lcd *LCDClient) RevokeSQLExec(ctx context.Context, grantee msg.AccAddress) (*sdk.TxResponse, error) {
+	return lcd.RevokeAuthorization(ctx, grantee, SQLExecMsgTypeURL)
+}
+
+// Exec Execute msgs on behalf of their signers, the client account must have been granted the authorization
+// Args:
+//   - msgs: Messages signed by the granters
+//
+// Returns:
+// Result of broadcasting MsgExec transaction
+func (lcd *LCDClient) Exec(ctx context.Context, msgs ...msg.Msg) (*sdk.TxResponse, error) {
+	_msg, err := lcd.newExecMsg(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
+
+func (lcd *LCDClient) newExecMsg(msgs ...msg.Msg) (*authz.MsgExec, error) {
+	msgsAny := make([]*cdctypes.Any, len(msgs))
+	for i, m := range msgs {
+		any, err := cdctypes.NewAnyWithValue(m)
+		if err != nil {
+			return nil, err
+		}
+		msgsAny[i] = any
+	}
+	return &authz.MsgExec{Grantee: lcd.Bech32Address(), Msgs: msgsAny}, nil
+}
+
+// SQLExecOnBehalfOf Execute a SQL as uid, the client account must have been granted by GrantSQLExec
+// Args:
+//   - uid: Address of the user the SQL is executed as
+//   - sql: SQL statement to execute
+//   - args: Parameters of the SQL statement, default to None
+//
+// Returns:
+// Transaction information of the SQL execution
+func (lcd *LCDClient) SQLExecOnBehalfOf(ctx context.Context, uid msg.AccAddress, sql string, args []*glittertypes.Argument) (*sdk.TxResponse, error) {
+	return lcd.Exec(ctx, newSQLExecRequest(lcd.bech32(uid), sql, args))
+}
+
+// Grants Query the authorizations granted by granter to grantee
+// Args:
+//   - granter: Address of the granter
+//   - grantee: Address of the grantee
+//   - msgTypeURL: Only return grants of this message type, optional
+//
+// Returns:
+// The matching grants
+func (lcd *LCDClient) Grants(ctx context.Context, granter, grantee msg.AccAddress, msgTypeURL string) ([]*authz.Grant, error) {
+	uv := url.Values{}
+	uv.Add("granter", lcd.bech32(granter))
+	uv.Add("grantee", lcd.bech32(grantee))
+	if len(msgTypeURL) > 0 {
+		uv.Add("msg_type_url", msgTypeURL)
+	}
+	var response authz.QueryGrantsResponse
+	if err := lcd.queryJSON(ctx, "/cosmos/authz/v1beta1/grants?"+uv.Encode(), &response); err != nil {
+		return nil, err
+	}
+	for _, g := range response.Grants {
+		if err := lcd.unpackGrant(g); err != nil {
+			return nil, err
+		}
+	}
+	return response.Grants, nil
+}
+
+// GranterGrants Query all authorizations granted by granter
+func (lcd *LCDClient) GranterGrants(ctx context.Context, granter msg.AccAddress) ([]*authz.GrantAuthorization, error) {
+	var response authz.QueryGranterGrantsResponse
+	if err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/authz/v1beta1/grants/granter/%s", lcd.bech32(granter)), &response); err != nil {
+		return nil, err
+	}
+	for _, g := range response.Grants {
+		if err := lcd.unpackGrant(g); err != nil {
+			return nil, err
+		}
+	}
+	return response.Grants, nil
+}
+
+// GranteeGrants Query all authorizations granted to grantee
+func (lcd *LCDClient) GranteeGrants(ctx context.Context, grantee msg.AccAddress) ([]*authz.GrantAuthorization, error) {
+	var response authz.QueryGranteeGrantsResponse
+	if err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/authz/v1beta1/grants/grantee/%s", lcd.bech32(grantee)), &response); err != nil {
+		return nil, err
+	}
+	for _, g := range response.Grants {
+		if err := lcd.unpackGrant(g); err != nil {
+			return nil, err
+		}
+	}
+	return response.Grants, nil
+}
+
+// unpackGrant cache the authorization of a grant of a query response, the query responses of the sdk
+// do not unpack the grants they hold, so GetAuthorization would return nil
+func (lcd *LCDClient) unpackGrant(grant cdctypes.UnpackInterfacesMessage) error {
+	if err := grant.UnpackInterfaces(lcd.EncodingConfig.InterfaceRegistry); err != nil {
+		return sdkerrors.Wrap(err, "failed to unpack grant")
+	}
+	return nil
+}
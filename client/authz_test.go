@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AuthzMsgs(t *testing.T) {
+	lcd := newTestClient(t)
+	user := msg.AccAddress(make([]byte, 20))
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	grant, err := lcd.newGrantMsg(user, authz.NewGenericAuthorization(SQLExecMsgTypeURL), expiration)
+	require.NoError(t, err)
+	assert.Equal(t, lcd.Bech32Address(), grant.Granter)
+	assert.Equal(t, lcd.bech32(user), grant.Grantee)
+	assert.Equal(t, expiration, grant.Grant.Expiration)
+	assert.Equal(t, SQLExecMsgTypeURL, grant.Grant.GetAuthorization().MsgTypeURL())
+
+	revoke := lcd.newRevokeMsg(user, SQLExecMsgTypeURL)
+	assert.Equal(t, lcd.Bech32Address(), revoke.Granter)
+	assert.Equal(t, lcd.bech32(user), revoke.Grantee)
+	assert.Equal(t, SQLExecMsgTypeURL, revoke.MsgTypeUrl)
+
+	// SQLExecOnBehalfOf wraps the SQL of the user in a MsgExec of the client
+	args := []*glittertypes.Argument{{Type: glittertypes.Argument_INT, Value: "1"}}
+	exec, err := lcd.newExecMsg(newSQLExecRequest(lcd.bech32(user), "insert into db.t values (?)", args))
+	require.NoError(t, err)
+	assert.Equal(t, lcd.Bech32Address(), exec.Grantee)
+	require.Len(t, exec.Msgs, 1)
+	assert.Equal(t, SQLExecMsgTypeURL, exec.Msgs[0].TypeUrl)
+	msgs, err := exec.GetMessages()
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	sqlExec := msgs[0].(*glittertypes.SQLExecRequest)
+	assert.Equal(t, lcd.bech32(user), sqlExec.Uid)
+	assert.Equal(t, "insert into db.t values (?)", sqlExec.Sql)
+	assert.Equal(t, args, sqlExec.Arguments)
+}
+
+func Test_Grants(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	granter, grantee := msg.AccAddress(make([]byte, 20)), msg.AccAddress(make([]byte, 20))
+	grantee[0] = 1
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	grant, err := authz.NewGrant(authz.NewGenericAuthorization(SQLExecMsgTypeURL), expiration)
+	require.NoError(t, err)
+
+	grantsBody, err := lcd.GetMarshaler().MarshalJSON(&authz.QueryGrantsResponse{Grants: []*authz.Grant{&grant}})
+	require.NoError(t, err)
+	granterBody, err := lcd.GetMarshaler().MarshalJSON(&authz.QueryGranterGrantsResponse{Grants: []*authz.GrantAuthorization{{
+		Granter:       lcd.bech32(granter),
+		Grantee:       lcd.bech32(grantee),
+		Authorization: grant.Authorization,
+		Expiration:    expiration,
+	}}})
+	require.NoError(t, err)
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RequestURI()
+		switch r.URL.Path {
+		case "/cosmos/authz/v1beta1/grants":
+			w.Write(grantsBody)
+		case "/cosmos/authz/v1beta1/grants/granter/" + lcd.bech32(granter), "/cosmos/authz/v1beta1/grants/grantee/" + lcd.bech32(grantee):
+			w.Write(granterBody)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	ctx := context.Background()
+
+	grants, err := lcd.Grants(ctx, granter, grantee, SQLExecMsgTypeURL)
+	require.NoError(t, err)
+	assert.Contains(t, query, "msg_type_url=%2Fblockved.glitterchain.index.SQLExecRequest")
+	require.Len(t, grants, 1)
+	assert.Equal(t, expiration, grants[0].Expiration)
+	require.NotNil(t, grants[0].GetAuthorization())
+	assert.Equal(t, SQLExecMsgTypeURL, grants[0].GetAuthorization().MsgTypeURL())
+
+	granterGrants, err := lcd.GranterGrants(ctx, granter)
+	require.NoError(t, err)
+	granteeGrants, err := lcd.GranteeGrants(ctx, grantee)
+	require.NoError(t, err)
+	for _, grants := range [][]*authz.GrantAuthorization{granterGrants, granteeGrants} {
+		require.Len(t, grants, 1)
+		assert.Equal(t, lcd.bech32(grantee), grants[0].Grantee)
+		authorization, ok := grants[0].Authorization.GetCachedValue().(authz.Authorization)
+		require.True(t, ok)
+		assert.Equal(t, SQLExecMsgTypeURL, authorization.MsgTypeURL())
+	}
+}
@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+)
+
+// SendOutput recipient and amount of a MultiSend
+type SendOutput struct {
+	To     msg.AccAddress
+	Amount msg.DecCoins
+}
+
+// pageQuery encode page as rest pagination query values
+func pageQuery(uv url.Values, page *query.PageRequest) {
+	if page == nil {
+		return
+	}
+	if len(page.Key) > 0 {
+		uv.Add("pagination.key", base64.StdEncoding.EncodeToString(page.Key))
+	}
+	if page.Offset > 0 {
+		uv.Add("pagination.offset", strconv.FormatUint(page.Offset, 10))
+	}
+	if page.Limit > 0 {
+		uv.Add("pagination.limit", strconv.FormatUint(page.Limit, 10))
+	}
+	if page.CountTotal {
+		uv.Add("pagination.count_total", "true")
+	}
+	if page.Reverse {
+		uv.Add("pagination.reverse", "true")
+	}
+}
+
+// Balance Query the balance of address in denom
+// Args:
+//   - address: Account address
+//   - denom: Base denom, such as agli
+//
+// Returns:
+// The balance coin
+func (lcd *LCDClient) Balance(ctx context.Context, address msg.AccAddress, denom string) (*msg.Coin, error) {
+	uv := url.Values{}
+	uv.Add("denom", denom)
+	var response banktypes.QueryBalanceResponse
+	err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/bank/v1beta1/balances/%s/by_denom?%s", lcd.bech32(address), uv.Encode()), &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Balance, nil
+}
+
+// AllBalances Query the balances of address in all denoms
+// Args:
+//   - address: Account address
+//   - page: Pagination of the denoms, optional
+//
+// Returns:
+// The balances and the pagination of the next page
+func (lcd *LCDClient) AllBalances(ctx context.Context, address msg.AccAddress, page *query.PageRequest) (msg.Coins, *query.PageResponse, error) {
+	uv := url.Values{}
+	pageQuery(uv, page)
+	var response banktypes.QueryAllBalancesResponse
+	err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/bank/v1beta1/balances/%s?%s", lcd.bech32(address), uv.Encode()), &response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Balances, response.Pagination, nil
+}
+
+// DenomsMetadata Query the metadata of all denoms, the result is cached by the client
+func (lcd *LCDClient) DenomsMetadata(ctx context.Context) ([]banktypes.Metadata, error) {
+	lcd.mu.Lock()
+	cached := lcd.denomsMetadata
+	lcd.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	// not nil, so a chain without metadata is cached too
+	metadatas := []banktypes.Metadata{}
+	page := &query.PageRequest{}
+	for {
+		uv := url.Values{}
+		pageQuery(uv, page)
+		var response banktypes.QueryDenomsMetadataResponse
+		if err := lcd.queryJSON(ctx, "/cosmos/bank/v1beta1/denoms_metadata?"+uv.Encode(), &response); err != nil {
+			return nil, err
+		}
+		metadatas = append(metadatas, response.Metadatas...)
+		if response.Pagination == nil || len(response.Pagination.NextKey) == 0 {
+			break
+		}
+		page.Key = response.Pagination.NextKey
+	}
+
+	lcd.mu.Lock()
+	defer lcd.mu.Unlock()
+	lcd.denomsMetadata = metadatas
+	return metadatas, nil
+}
+
+// SupplyOf Query the total supply of denom
+// Args:
+//   - denom: Base denom, such as agli
+//
+// Returns:
+// The supply coin, zero if the chain holds none of denom
+func (lcd *LCDClient) SupplyOf(ctx context.Context, denom string) (*msg.Coin, error) {
+	var response banktypes.QuerySupplyOfResponse
+	if err := lcd.queryJSON(ctx, "/cosmos/bank/v1beta1/supply/"+url.PathEscape(denom), &response); err != nil {
+		return nil, err
+	}
+	return &response.Amount, nil
+}
+
+// ToBaseCoins Convert amounts in any denom unit, such as GLI, to base denom coins, such as agli,
+// using the chain denom metadata. Denoms without metadata must be base denoms the chain holds a supply of.
+func (lcd *LCDClient) ToBaseCoins(ctx context.Context, amount msg.DecCoins) (msg.Coins, error) {
+	metadatas, err := lcd.DenomsMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coins := msg.Coins{}
+	for _, c := range amount {
+		base, exponent, ok := findDenomUnit(metadatas, c.Denom)
+		if !ok {
+			supply, err := lcd.SupplyOf(ctx, c.Denom)
+			if err != nil {
+				return nil, err
+			}
+			if !supply.IsPositive() {
+				return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "unknown denom %s, it has no metadata and no supply", c.Denom)
+			}
+		}
+		baseAmount := c.Amount.Mul(sdk.NewDecFromInt(sdk.NewIntWithDecimal(1, int(exponent))))
+		if !baseAmount.IsInteger() {
+			return nil, fmt.Errorf("amount %s is smaller than 1%s", c, base)
+		}
+		coins = coins.Add(msg.NewCoin(base, baseAmount.TruncateInt()))
+	}
+	return coins, nil
+}
+
+// ParseAmount Parse an amount such as "1.5GLI" or "1000agli,2GLI" to base denom coins
+func (lcd *LCDClient) ParseAmount(ctx context.Context, amount string) (msg.Coins, error) {
+	decCoins, err := sdk.ParseDecCoins(amount)
+	if err != nil {
+		return nil, err
+	}
+	return lcd.ToBaseCoins(ctx, decCoins)
+}
+
+// findDenomUnit returns the base denom of denom and the exponent of denom relative to it,
+// ok is false if no metadata has denom as a unit or base
+func findDenomUnit(metadatas []banktypes.Metadata, denom string) (base string, exponent uint32, ok bool) {
+	for _, m := range metadatas {
+		if m.Base == denom {
+			return m.Base, 0, true
+		}
+		for _, u := range m.DenomUnits {
+			if strings.EqualFold(u.Denom, denom) {
+				return m.Base, u.Exponent, true
+			}
+			for _, alias := range u.Aliases {
+				if strings.EqualFold(alias, denom) {
+					return m.Base, u.Exponent, true
+				}
+			}
+		}
+	}
+	return denom, 0, false
+}
+
+// Send Transfer amount from the client account to address to
+// Args:
+//   - to: Recipient address
+//   - amount: Amount in base or display units, such as 1000agli or 1.5GLI
+//
+// Returns:
+// Result of broadcasting send transaction
+func (lcd *LCDClient) Send(ctx context.Context, to msg.AccAddress, amount msg.DecCoins) (*sdk.TxResponse, error) {
+	coins, err := lcd.ToBaseCoins(ctx, amount)
+	if err != nil {
+		return nil, err
+	}
+	_msg := &msg.Send{FromAddress: lcd.Bech32Address(), ToAddress: lcd.bech32(to), Amount: coins}
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
+
+// MultiSend Transfer to several addresses from the client account in one tx
+// Args:
+//   - outputs: Recipients and amounts in base or display units
+//
+// Returns:
+// Result of broadcasting multi-send transaction
+func (lcd *LCDClient) MultiSend(ctx context.Context, outputs []SendOutput) (*sdk.TxResponse, error) {
+	total := msg.Coins{}
+	bankOutputs := make([]msg.Output, 0, len(outputs))
+	for _, o := range outputs {
+		coins, err := lcd.ToBaseCoins(ctx, o.Amount)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(coins...)
+		bankOutputs = append(bankOutputs, msg.Output{Address: lcd.bech32(o.To), Coins: coins})
+	}
+	_msg := &msg.MultiSend{Inputs: []msg.Input{{Address: lcd.Bech32Address(), Coins: total}}, Outputs: bankOutputs}
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
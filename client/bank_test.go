@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAmount(t *testing.T) {
+	var metadataQueries int
+	lcd := New("glitter_12000-2", nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cosmos/bank/v1beta1/denoms_metadata" {
+			metadataQueries++
+			w.Write([]byte(`{"metadatas":[]}`))
+			return
+		}
+		// only stake has a supply
+		supply := msg.NewInt64Coin("GLI", 0)
+		if r.URL.Path == "/cosmos/bank/v1beta1/supply/stake" {
+			supply = msg.NewInt64Coin("stake", 1000)
+		}
+		bz, err := lcd.GetMarshaler().MarshalJSON(&banktypes.QuerySupplyOfResponse{Amount: supply})
+		require.NoError(t, err)
+		w.Write(bz)
+	}))
+	defer srv.Close()
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	ctx := context.Background()
+
+	// the empty metadata of the chain is cached
+	coins, err := lcd.ParseAmount(ctx, "7stake")
+	assert.NoError(t, err)
+	assert.Equal(t, "7stake", coins.String())
+	_, err = lcd.ParseAmount(ctx, "2GLI")
+	assert.Error(t, err)
+	assert.Equal(t, 1, metadataQueries)
+
+	lcd.denomsMetadata = []banktypes.Metadata{{
+		Base:    "agli",
+		Display: "gli",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: "agli", Exponent: 0},
+			{Denom: "gli", Exponent: 18},
+		},
+	}}
+
+	coins, err = lcd.ParseAmount(ctx, "1.5GLI,1000agli")
+	assert.NoError(t, err)
+	assert.Equal(t, "1500000000000001000agli", coins.String())
+
+	_, err = lcd.ParseAmount(ctx, "0.5agli")
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// msgEncodingOverhead bytes added by wrapping a msg in an Any of the tx body
+const msgEncodingOverhead = 8
+
+// ErrBatchBudgetExceeded the batch exceeds its byte or gas budget
+var ErrBatchBudgetExceeded = errors.New("batch budget exceeded")
+
+// Batch SQL statements signed in one tx, they are committed all together or not at all
+type Batch struct {
+	lcd      *LCDClient
+	maxBytes int
+	maxGas   uint64
+
+	msgs  []*glittertypes.SQLExecRequest
+	bytes int
+}
+
+// StatementResult result of a statement of a committed batch
+type StatementResult struct {
+	// SQL the statement
+	SQL string
+	// Response decoded SQLExecResponse of the statement
+	Response *glittertypes.SQLExecResponse
+	// Events events emitted by the statement
+	Events sdk.StringEvents
+}
+
+// BatchResult result of a committed batch
+type BatchResult struct {
+	*ExecResult
+	// Statements results in the order the statements were added
+	Statements []StatementResult
+}
+
+// NewBatch create an empty batch of the client account
+// Args:
+//   - maxBytes: Max encoded bytes of the batch messages, 0 for no limit
+//   - maxGas: Max gas limit of the batch tx, 0 for no limit
+//
+// Returns:
+// The batch, statements added beyond maxBytes are rejected with ErrBatchBudgetExceeded
+func (lcd *LCDClient) NewBatch(maxBytes int, maxGas uint64) *Batch {
+	return &Batch{
+		lcd:      lcd,
+		maxBytes: maxBytes,
+		maxGas:   maxGas,
+	}
+}
+
+// Len number of statements in the batch
+func (b *Batch) Len() int {
+	return len(b.msgs)
+}
+
+// Bytes encoded bytes of the batch messages
+func (b *Batch) Bytes() int {
+	return b.bytes
+}
+
+// Reset remove all statements from the batch
+func (b *Batch) Reset() {
+	b.msgs = nil
+	b.bytes = 0
+}
+
+// Exec add a SQL statement to the batch
+func (b *Batch) Exec(sql string, args []*glittertypes.Argument) error {
+	_msg := b.lcd.newSQLExecRequest(sql, args)
+	size := proto.Size(_msg) + len(SQLExecMsgTypeURL) + msgEncodingOverhead
+	if b.maxBytes > 0 && b.bytes+size > b.maxBytes {
+		return fmt.Errorf("%w: %d bytes exceeds max bytes %d", ErrBatchBudgetExceeded, b.bytes+size, b.maxBytes)
+	}
+	b.msgs = append(b.msgs, _msg)
+	b.bytes += size
+	return nil
+}
+
+// CreateTable add a CREATE TABLE statement to the batch
+func (b *Batch) CreateTable(sql string) error {
+	return b.Exec(sql, nil)
+}
+
+// Insert add an INSERT of a row to the batch, see LCDClient.Insert
+func (b *Batch) Insert(db, table string, columns map[string]interface{}) error {
+	sql, args, err := utils.BuildInsertStatement(utils.FullTableName(db, table), columns)
+	if err != nil {
+		return err
+	}
+	return b.Exec(sql, args)
+}
+
+// BatchInsert add an INSERT of multiple rows to the batch, see LCDClient.BatchInsert
+func (b *Batch) BatchInsert(db, table string, columns []string, rowValues [][]interface{}) error {
+	sql, args, err := utils.BuildBatchInsertStatement(utils.FullTableName(db, table), columns, rowValues)
+	if err != nil {
+		return err
+	}
+	return b.Exec(sql, args)
+}
+
+// Update add an UPDATE to the batch, see LCDClient.Update
+func (b *Batch) Update(db, table string, columns map[string]interface{}, where map[string]interface{}) error {
+	sql, args, err := utils.BuildUpdateStatement(utils.FullTableName(db, table), columns, where)
+	if err != nil {
+		return err
+	}
+	return b.Exec(sql, args)
+}
+
+// Delete add a DELETE to the batch, see LCDClient.Delete
+func (b *Batch) Delete(db, table string, where map[string]interface{}, orderBy string, asc bool, limit int) error {
+	sql, args, err := utils.BuildDeleteStatement(utils.FullTableName(db, table), where, orderBy, asc, limit)
+	if err != nil {
+		return err
+	}
+	return b.Exec(sql, args)
+}
+
+// Commit Sign the batch statements in one tx and wait until it is committed
+// Args:
+//   - options: Tx options, Msgs is set to the batch statements
+//
+// Returns:
+// The raw response of the committed tx and the result of every statement,
+// the tx is not broadcast if its gas limit exceeds the batch gas budget, the batch is reset once committed
+func (b *Batch) Commit(ctx context.Context, options CreateTxOptions) (*sdk.TxResponse, *BatchResult, error) {
+	if len(b.msgs) == 0 {
+		return nil, nil, fmt.Errorf("empty batch")
+	}
+	options.Msgs = make([]msg.Msg, len(b.msgs))
+	for i, m := range b.msgs {
+		options.Msgs[i] = m
+	}
+
+	// the gas budget is checked once the gas limit is estimated, before signing
+	options.maxGas = b.maxGas
+	txResponse, res, err := b.lcd.signBroadcastAndWait(ctx, options)
+	if err != nil {
+		return txResponse, nil, err
+	}
+
+	batchRes := &BatchResult{ExecResult: res, Statements: make([]StatementResult, len(b.msgs))}
+	for i, m := range b.msgs {
+		batchRes.Statements[i] = StatementResult{SQL: m.Sql}
+		if len(res.Responses) == len(b.msgs) {
+			batchRes.Statements[i].Response = res.Responses[i]
+		}
+	}
+	// logs are reported per message once committed, a dry run reports a single log
+	if len(txResponse.Logs) == len(b.msgs) {
+		for _, log := range txResponse.Logs {
+			if int(log.MsgIndex) >= len(b.msgs) {
+				continue
+			}
+			batchRes.Statements[log.MsgIndex].Events = log.Events
+		}
+	}
+	b.Reset()
+	return txResponse, batchRes, nil
+}
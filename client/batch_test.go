@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Batch(t *testing.T) {
+
+	var simulated []byte
+	simulations := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		simulations++
+		w.Write(simulated)
+	}))
+	defer srv.Close()
+
+	lcd := newTestClient(t, WithChainEndpoint(srv.URL), WithDryRun(true), WithFixedGasPrice())
+	responseData, err := proto.Marshal(&glittertypes.SQLExecResponse{})
+	assert.NoError(t, err)
+	txMsgData, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{
+		{MsgType: SQLExecMsgTypeURL, Data: responseData},
+		{MsgType: SQLExecMsgTypeURL, Data: responseData},
+	}})
+	assert.NoError(t, err)
+	simulated, err = lcd.GetMarshaler().MarshalJSON(&sdktx.SimulateResponse{
+		GasInfo: &sdk.GasInfo{GasUsed: 1000},
+		Result:  &sdk.Result{Data: txMsgData},
+	})
+	assert.NoError(t, err)
+
+	batch := lcd.NewBatch(400, 2000)
+	assert.NoError(t, batch.Insert("library", "ebook", map[string]interface{}{"title": "Dune"}))
+	assert.NoError(t, batch.Delete("library", "author", map[string]interface{}{"name": "nobody"}, "", false, 0))
+	assert.Equal(t, 2, batch.Len())
+	err = batch.Exec("insert into library.ebook (title) values (?)", []*glittertypes.Argument{{Value: string(make([]byte, 400))}})
+	assert.True(t, errors.Is(err, ErrBatchBudgetExceeded))
+	assert.Equal(t, 2, batch.Len())
+
+	options := CreateTxOptions{AccountNumber: 1, Sequence: 1}
+	_, _, err = batch.Commit(context.Background(), options)
+	assert.True(t, errors.Is(err, ErrBatchBudgetExceeded))
+	// the gas budget is checked on the gas limit of the only simulation
+	assert.Equal(t, 1, simulations)
+
+	batch = lcd.NewBatch(0, 0)
+	assert.NoError(t, batch.Insert("library", "ebook", map[string]interface{}{"title": "Dune"}))
+	assert.NoError(t, batch.Delete("library", "author", map[string]interface{}{"name": "nobody"}, "", false, 0))
+	_, res, err := batch.Commit(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Len(t, res.Statements, 2)
+	assert.Equal(t, "DELETE FROM library.author WHERE name=?", res.Statements[1].SQL)
+	assert.NotNil(t, res.Statements[1].Response)
+	assert.Equal(t, 0, batch.Len())
+}
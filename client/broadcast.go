@@ -20,7 +20,20 @@ func (lcd *LCDClient) Broadcast(ctx context.Context, txbuilder *tx.Builder) (*sd
 	if err != nil {
 		return nil, err
 	}
+	return lcd.BroadcastTxBytes(ctx, txBytes)
+}
+
+// BroadcastTxJSON broadcast a signed tx json, such as the output of SignTx or `glitterd tx sign`
+func (lcd *LCDClient) BroadcastTxJSON(ctx context.Context, txJSON []byte) (*sdk.TxResponse, error) {
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), txJSON)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx")
+	}
+	return lcd.Broadcast(ctx, &txbuilder)
+}
 
+// BroadcastTxBytes broadcast signed and proto encoded tx bytes
+func (lcd *LCDClient) BroadcastTxBytes(ctx context.Context, txBytes []byte) (*sdk.TxResponse, error) {
 	broadcastReq := txtypes.BroadcastTxRequest{
 		TxBytes: txBytes,
 		Mode:    txtypes.BroadcastMode_BROADCAST_MODE_SYNC,
@@ -0,0 +1,439 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+)
+
+// default BulkLoadConfig values
+const (
+	DefaultBulkChunkBytes   = 512 * 1024
+	DefaultBulkChunkRows    = 1000
+	DefaultBulkConcurrency  = 4
+	DefaultBulkMaxRetries   = 3
+	DefaultBulkRetryBackoff = time.Second * 2
+)
+
+// bulkValueOverhead estimated encoded bytes of an argument besides its value
+const bulkValueOverhead = 8
+
+// RowSource rows read by a BulkLoader, Next returns io.EOF after the last row
+type RowSource interface {
+	Next() ([]interface{}, error)
+}
+
+type sliceRows struct {
+	rows [][]interface{}
+	next int
+}
+
+func (s *sliceRows) Next() ([]interface{}, error) {
+	if s.next >= len(s.rows) {
+		return nil, io.EOF
+	}
+	s.next++
+	return s.rows[s.next-1], nil
+}
+
+// SliceRows returns a RowSource of in memory rows
+func SliceRows(rows [][]interface{}) RowSource {
+	return &sliceRows{rows: rows}
+}
+
+// BulkLoadConfig config of a BulkLoader, zero values use the defaults
+type BulkLoadConfig struct {
+	// MaxChunkBytes max estimated bytes of the rows of a chunk
+	MaxChunkBytes int
+	// MaxChunkRows max rows of a chunk
+	MaxChunkRows int
+	// Concurrency number of chunks waiting for commit at the same time
+	Concurrency int
+	// MaxRetries retries of a failed chunk before the load is aborted
+	MaxRetries int
+	// RetryBackoff wait before retrying a chunk, doubled on every retry
+	RetryBackoff time.Duration
+	// CheckpointFile file recording the committed rows, a load resumes from it if it exists.
+	// A client in dry run mode commits nothing, so it reads but never writes the file.
+	CheckpointFile string
+	// OnProgress called after each committed chunk
+	OnProgress func(stats BulkLoadStats)
+}
+
+// BulkLoadStats progress of a bulk load
+type BulkLoadStats struct {
+	// Rows rows committed by this load, rows skipped from the checkpoint are not counted
+	Rows int64
+	// SkippedRows rows already committed according to the checkpoint, set once the load ends
+	SkippedRows int64
+	// Chunks chunks committed
+	Chunks int64
+	// Bytes estimated bytes of the committed rows
+	Bytes int64
+	// Retries chunk retries
+	Retries int64
+	// Elapsed time since the load started
+	Elapsed time.Duration
+}
+
+// RowsPerSecond committed rows per second
+func (s BulkLoadStats) RowsPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Rows) / s.Elapsed.Seconds()
+}
+
+// BytesPerSecond committed bytes per second
+func (s BulkLoadStats) BytesPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Bytes) / s.Elapsed.Seconds()
+}
+
+// BulkLoader insert a large number of rows into a table with chunked BatchInsert txs.
+// Chunks are sent through a SequenceManager, so several chunks wait for commit concurrently.
+// A client gas strategy such as NewLinearGasStrategy avoids simulating every chunk.
+type BulkLoader struct {
+	lcd       *LCDClient
+	db, table string
+	columns   []string
+	config    BulkLoadConfig
+	sequences *SequenceManager
+}
+
+// rowRange rows [Start, End) of the source
+type rowRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// bulkCheckpoint committed rows of a bulk load
+type bulkCheckpoint struct {
+	Database  string     `json:"database"`
+	Table     string     `json:"table"`
+	Committed []rowRange `json:"committed"`
+}
+
+type bulkChunk struct {
+	ranges []rowRange
+	rows   [][]interface{}
+	bytes  int
+}
+
+type bulkChunkResult struct {
+	chunk   *bulkChunk
+	retries int
+	err     error
+}
+
+// NewBulkLoader create bulk loader inserting into db.table
+// Args:
+//   - db: The database name
+//   - table: The table name
+//   - columns: Column names of the source rows
+//   - config: Chunking, concurrency, retry and checkpoint config
+//
+// Returns:
+// The bulk loader, its chunks are signed by the client account
+func (lcd *LCDClient) NewBulkLoader(db, table string, columns []string, config BulkLoadConfig) *BulkLoader {
+	if config.MaxChunkBytes <= 0 {
+		config.MaxChunkBytes = DefaultBulkChunkBytes
+	}
+	if config.MaxChunkRows <= 0 {
+		config.MaxChunkRows = DefaultBulkChunkRows
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultBulkConcurrency
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = DefaultBulkMaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultBulkRetryBackoff
+	}
+	return &BulkLoader{
+		lcd:       lcd,
+		db:        db,
+		table:     table,
+		columns:   columns,
+		config:    config,
+		sequences: NewSequenceManager(lcd),
+	}
+}
+
+// Load Insert all rows of source, resuming from the checkpoint file if any
+// Args:
+//   - source: Rows to insert, in the order of the loader columns
+//
+// Returns:
+// The load stats, on error the checkpoint keeps the committed rows so the load can be resumed
+func (l *BulkLoader) Load(ctx context.Context, source RowSource) (BulkLoadStats, error) {
+	start := time.Now()
+	checkpoint, err := l.readCheckpoint()
+	if err != nil {
+		return BulkLoadStats{}, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stats BulkLoadStats
+	var skipped int64
+	var produceErr error
+	chunks := make(chan *bulkChunk)
+	committed := append([]rowRange(nil), checkpoint.Committed...)
+	go func() {
+		defer close(chunks)
+		skipped, produceErr = l.produceChunks(ctx, source, committed, chunks)
+	}()
+
+	results := make(chan bulkChunkResult)
+	wg := sync.WaitGroup{}
+	for i := 0; i < l.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				retries, err := l.sendChunk(ctx, chunk)
+				results <- bulkChunkResult{chunk: chunk, retries: retries, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var loadErr error
+	for res := range results {
+		stats.Retries += int64(res.retries)
+		if res.err != nil {
+			if loadErr == nil {
+				loadErr = res.err
+				cancel()
+			}
+			continue
+		}
+		if !l.lcd.dryRun {
+			checkpoint.Committed = mergeRowRanges(append(checkpoint.Committed, res.chunk.ranges...))
+			if err := l.writeCheckpoint(checkpoint); err != nil && loadErr == nil {
+				loadErr = err
+				cancel()
+			}
+		}
+		stats.Rows += int64(len(res.chunk.rows))
+		stats.Chunks++
+		stats.Bytes += int64(res.chunk.bytes)
+		stats.Elapsed = time.Since(start)
+		if l.config.OnProgress != nil {
+			l.config.OnProgress(stats)
+		}
+	}
+	stats.Elapsed = time.Since(start)
+	stats.SkippedRows = skipped
+	if loadErr != nil {
+		return stats, loadErr
+	}
+	return stats, produceErr
+}
+
+// produceChunks read rows of source not yet committed and group them into chunks
+func (l *BulkLoader) produceChunks(ctx context.Context, source RowSource, committed []rowRange, chunks chan<- *bulkChunk) (int64, error) {
+	var skipped int64
+	chunk := &bulkChunk{}
+	send := func() error {
+		if len(chunk.rows) == 0 {
+			return nil
+		}
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		chunk = &bulkChunk{}
+		return nil
+	}
+
+	for i := int64(0); ; i++ {
+		row, err := source.Next()
+		if err == io.EOF {
+			return skipped, send()
+		}
+		if err != nil {
+			return skipped, fmt.Errorf("failed to read row %d: %w", i, err)
+		}
+		for len(committed) > 0 && committed[0].End <= i {
+			committed = committed[1:]
+		}
+		if len(committed) > 0 && committed[0].Start <= i {
+			skipped++
+			continue
+		}
+		if len(row) != len(l.columns) {
+			return skipped, fmt.Errorf("row %d has %d values, expected %d columns", i, len(row), len(l.columns))
+		}
+
+		size := estimateRowBytes(row)
+		if len(chunk.rows) > 0 && (len(chunk.rows) >= l.config.MaxChunkRows || chunk.bytes+size > l.config.MaxChunkBytes) {
+			if err := send(); err != nil {
+				return skipped, err
+			}
+		}
+		chunk.rows = append(chunk.rows, row)
+		chunk.bytes += size
+		if n := len(chunk.ranges); n > 0 && chunk.ranges[n-1].End == i {
+			chunk.ranges[n-1].End++
+		} else {
+			chunk.ranges = append(chunk.ranges, rowRange{Start: i, End: i + 1})
+		}
+	}
+}
+
+// sendChunk insert the chunk and wait for its commit, retrying on failure
+func (l *BulkLoader) sendChunk(ctx context.Context, chunk *bulkChunk) (int, error) {
+	sql, args, err := utils.BuildBatchInsertStatement(utils.FullTableName(l.db, l.table), l.columns, chunk.rows)
+	if err != nil {
+		return 0, err
+	}
+	_msg := l.lcd.newSQLExecRequest(sql, args)
+
+	backoff := l.config.RetryBackoff
+	txHash := ""
+	for retries := 0; ; retries++ {
+		// a previous attempt may have been committed after its wait failed
+		if len(txHash) > 0 {
+			if committed, err := l.lcd.GetTx(ctx, txHash); err == nil && committed.TxResponse.Code == 0 {
+				return retries, nil
+			}
+		}
+
+		txResponse, err := l.sequences.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}, SignMode: tx.SignModeDirect})
+		if err == nil && !l.lcd.dryRun {
+			txHash = txResponse.TxHash
+			var committed *txtypes.GetTxResponse
+			committed, err = l.lcd.WaitTx(ctx, txHash)
+			if err == nil {
+				l.lcd.observeGasUsed(committed)
+			}
+			if err == nil && committed.TxResponse.Code != 0 {
+				err = fmt.Errorf("tx failed with code %d: %s", committed.TxResponse.Code, committed.TxResponse.RawLog)
+				txHash = ""
+			}
+		}
+		if err == nil {
+			return retries, nil
+		}
+		if ctx.Err() != nil || retries >= l.config.MaxRetries {
+			return retries, fmt.Errorf("failed to insert rows %d-%d: %w", chunk.ranges[0].Start, chunk.ranges[len(chunk.ranges)-1].End-1, err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return retries, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// readCheckpoint returns the checkpoint of the load, empty if there is no checkpoint file
+func (l *BulkLoader) readCheckpoint() (*bulkCheckpoint, error) {
+	checkpoint := &bulkCheckpoint{Database: l.db, Table: l.table}
+	if len(l.config.CheckpointFile) == 0 {
+		return checkpoint, nil
+	}
+	bz, err := ioutil.ReadFile(l.config.CheckpointFile)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(bz, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if checkpoint.Database != l.db || checkpoint.Table != l.table {
+		return nil, fmt.Errorf("checkpoint %s is of table %s", l.config.CheckpointFile, utils.FullTableName(checkpoint.Database, checkpoint.Table))
+	}
+	checkpoint.Committed = mergeRowRanges(checkpoint.Committed)
+	return checkpoint, nil
+}
+
+// writeCheckpoint replace the checkpoint file atomically
+func (l *BulkLoader) writeCheckpoint(checkpoint *bulkCheckpoint) error {
+	if len(l.config.CheckpointFile) == 0 {
+		return nil
+	}
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(l.config.CheckpointFile), filepath.Base(l.config.CheckpointFile)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.config.CheckpointFile); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// mergeRowRanges sort ranges and merge the overlapping or adjacent ones
+func mergeRowRanges(ranges []rowRange) []rowRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			if r.End > merged[n-1].End {
+				merged[n-1].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// estimateRowBytes estimated encoded bytes of the arguments of row
+func estimateRowBytes(row []interface{}) int {
+	size := 0
+	for _, v := range row {
+		switch v := v.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += base64.StdEncoding.EncodedLen(len(v))
+		default:
+			size += len(fmt.Sprint(v))
+		}
+		size += bulkValueOverhead
+	}
+	return size
+}
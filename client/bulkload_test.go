@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BulkLoader(t *testing.T) {
+
+	var account, simulated []byte
+	simulateCalls, failAt := 0, 3
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cosmos/auth/v1beta1/accounts/") {
+			w.Write(account)
+			return
+		}
+		simulateCalls++
+		if simulateCalls == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":13,"message":"out of gas"}`))
+			return
+		}
+		w.Write(simulated)
+	}))
+	defer srv.Close()
+
+	lcd := newTestClient(t, WithChainEndpoint(srv.URL), WithDryRun(true), WithFixedGasPrice())
+	accountAny, err := codectypes.NewAnyWithValue(authtypes.NewBaseAccount(lcd.GetAddress(), nil, 5, 3))
+	assert.NoError(t, err)
+	account, err = lcd.GetMarshaler().MarshalJSON(&authtypes.QueryAccountResponse{Account: accountAny})
+	assert.NoError(t, err)
+	simulated, err = lcd.GetMarshaler().MarshalJSON(&sdktx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 1000}, Result: &sdk.Result{}})
+	assert.NoError(t, err)
+
+	rows := [][]interface{}{{"1", "Dune"}, {"2", "Emma"}, {"3", "Ulysses"}, {"4", "Walden"}, {"5", "Beloved"}}
+	config := BulkLoadConfig{
+		MaxChunkRows:   2,
+		Concurrency:    1,
+		MaxRetries:     -1,
+		CheckpointFile: filepath.Join(t.TempDir(), "ebook.checkpoint"),
+	}
+	loader := lcd.NewBulkLoader("library", "ebook", []string{"_id", "title"}, config)
+	stats, err := loader.Load(context.Background(), SliceRows(rows))
+	assert.Error(t, err)
+	assert.Equal(t, int64(4), stats.Rows)
+	assert.Equal(t, int64(2), stats.Chunks)
+
+	// a dry run commits nothing, so no checkpoint is written and every row is simulated again
+	_, err = os.Stat(config.CheckpointFile)
+	assert.True(t, os.IsNotExist(err))
+	stats, err = loader.Load(context.Background(), SliceRows(rows))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Rows)
+	assert.Equal(t, int64(0), stats.SkippedRows)
+	assert.Equal(t, 6, simulateCalls)
+	_, err = os.Stat(config.CheckpointFile)
+	assert.True(t, os.IsNotExist(err))
+
+	// an existing checkpoint is still resumed from
+	assert.NoError(t, loader.writeCheckpoint(&bulkCheckpoint{Database: "library", Table: "ebook", Committed: []rowRange{{0, 4}}}))
+	stats, err = loader.Load(context.Background(), SliceRows(rows))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Rows)
+	assert.Equal(t, int64(4), stats.SkippedRows)
+	assert.Equal(t, 7, simulateCalls)
+}
+
+func Test_MergeRowRanges(t *testing.T) {
+	assert.Equal(t, []rowRange{{0, 6}, {8, 9}}, mergeRowRanges([]rowRange{{4, 6}, {8, 9}, {0, 2}, {2, 4}}))
+}
@@ -0,0 +1,327 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// Change a SQL write replayed from the chain history by CDCReader
+type Change struct {
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	TxHash string    `json:"tx_hash"`
+	// MsgIndex index of the message among the SQLExecRequest of the tx
+	MsgIndex int    `json:"msg_index"`
+	Signer   string `json:"signer"`
+	// Database and Table written by the statement, Database is empty if the table is not qualified
+	Database      string                   `json:"database,omitempty"`
+	Table         string                   `json:"table,omitempty"`
+	StatementType string                   `json:"statement_type"`
+	SQL           string                   `json:"sql"`
+	Arguments     []*glittertypes.Argument `json:"arguments,omitempty"`
+}
+
+func newChange(e *SQLEvent) *Change {
+	return &Change{
+		Height:        e.Height,
+		Time:          e.Time,
+		TxHash:        e.TxHash,
+		MsgIndex:      e.MsgIndex,
+		Signer:        e.Signer,
+		Database:      e.Database,
+		Table:         e.Table,
+		StatementType: e.StatementType,
+		SQL:           e.Message.Sql,
+		Arguments:     e.Message.Arguments,
+	}
+}
+
+// Checkpoint position of a CDCReader in the chain history
+type Checkpoint struct {
+	// Height height of the last replayed tx
+	Height int64 `json:"height"`
+	// TxHashes replayed txs of Height
+	TxHashes []string `json:"tx_hashes,omitempty"`
+}
+
+// replayed returns whether the tx is replayed before the checkpoint
+func (c *Checkpoint) replayed(height int64, txHash string) bool {
+	if height != c.Height {
+		return height < c.Height
+	}
+	for _, h := range c.TxHashes {
+		if h == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// advance move the checkpoint past the tx
+func (c *Checkpoint) advance(height int64, txHash string) {
+	if height > c.Height {
+		c.Height = height
+		c.TxHashes = nil
+	}
+	c.TxHashes = append(c.TxHashes, txHash)
+}
+
+func (c *Checkpoint) copy() *Checkpoint {
+	return &Checkpoint{Height: c.Height, TxHashes: append([]string(nil), c.TxHashes...)}
+}
+
+// CheckpointStore persists the checkpoint of a CDCReader
+type CheckpointStore interface {
+	// LoadCheckpoint returns the saved checkpoint, nil if none is saved
+	LoadCheckpoint() (*Checkpoint, error)
+	// SaveCheckpoint save the checkpoint, replacing the saved one
+	SaveCheckpoint(checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore checkpoint store of a process
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// LoadCheckpoint implements CheckpointStore
+func (s *MemoryCheckpointStore) LoadCheckpoint() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	return s.checkpoint.copy(), nil
+}
+
+// SaveCheckpoint implements CheckpointStore
+func (s *MemoryCheckpointStore) SaveCheckpoint(checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint.copy()
+	return nil
+}
+
+// FileCheckpointStore checkpoint store of a json file, the file is replaced atomically on save
+type FileCheckpointStore struct {
+	Path string
+}
+
+// LoadCheckpoint implements CheckpointStore
+func (s *FileCheckpointStore) LoadCheckpoint() (*Checkpoint, error) {
+	bz, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(bz, &checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %w", s.Path, err)
+	}
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint implements CheckpointStore
+func (s *FileCheckpointStore) SaveCheckpoint(checkpoint *Checkpoint) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// ChangeSink receives the change stream of a CDCReader
+type ChangeSink interface {
+	// Write write the changes of a tx, the txs are written in commit order
+	Write(changes []*Change) error
+}
+
+// ChangeSinkFunc adapts a func to ChangeSink
+type ChangeSinkFunc func(changes []*Change) error
+
+// Write implements ChangeSink
+func (f ChangeSinkFunc) Write(changes []*Change) error {
+	return f(changes)
+}
+
+// MemorySink sink keeping the changes in memory
+type MemorySink struct {
+	mu      sync.Mutex
+	changes []*Change
+}
+
+// Write implements ChangeSink
+func (s *MemorySink) Write(changes []*Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, changes...)
+	return nil
+}
+
+// Changes returns the written changes in order
+func (s *MemorySink) Changes() []*Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Change(nil), s.changes...)
+}
+
+// JSONLSink sink appending the changes to a file, one json object per line
+type JSONLSink struct {
+	f *os.File
+}
+
+// NewJSONLSink open the file of path for appending, it is created if not exists
+func NewJSONLSink(path string) (*JSONLSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{f: f}, nil
+}
+
+// Write implements ChangeSink, the changes are synced to disk before it returns
+func (s *JSONLSink) Write(changes []*Change) error {
+	w := bufio.NewWriter(s.f)
+	enc := json.NewEncoder(w)
+	for _, c := range changes {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// Close close the file
+func (s *JSONLSink) Close() error {
+	return s.f.Close()
+}
+
+// CDCReader replays the SQL writes committed on chain, it reads the tx search index of the node,
+// so the node must index txs
+type CDCReader struct {
+	lcd         *LCDClient
+	filter      SQLEventFilter
+	checkpoints CheckpointStore
+}
+
+// NewCDCReader create reader of the SQL writes selected by filter
+// Args:
+//   - filter: selects the replayed writes, the writes are replayed from filter.FromHeight
+//     or the saved checkpoint, whichever is higher
+//   - checkpoints: saves the position of the reader, nil replays from filter.FromHeight on every Run
+//
+// Returns:
+// The reader
+func (lcd *LCDClient) NewCDCReader(filter SQLEventFilter, checkpoints CheckpointStore) (*CDCReader, error) {
+	if len(filter.Signer) > 0 {
+		signer, err := lcd.normalizeUID(filter.Signer)
+		if err != nil {
+			return nil, err
+		}
+		filter.Signer = signer
+	}
+	return &CDCReader{lcd: lcd, filter: filter, checkpoints: checkpoints}, nil
+}
+
+// Run Replay the writes committed after the checkpoint into sink
+// Args:
+//   - sink: receives the changes of every tx in commit order, the checkpoint is saved
+//     after the changes are written
+//
+// Returns:
+// The checkpoint after the newest replayed tx, Run again to replay the txs committed since
+func (r *CDCReader) Run(ctx context.Context, sink ChangeSink) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{}
+	if r.checkpoints != nil {
+		saved, err := r.checkpoints.LoadCheckpoint()
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to load checkpoint")
+		}
+		if saved != nil {
+			checkpoint = saved
+		}
+	}
+	from := checkpoint.Height
+	if r.filter.FromHeight > from {
+		from = r.filter.FromHeight
+	}
+
+	// the SQL executed on behalf of a granter is committed in a MsgExec
+	var events []string
+	if from > 0 {
+		events = append(events, fmt.Sprintf("tx.height>=%d", from))
+	}
+	err := r.lcd.walkActionTxs(ctx, sqlExecActions, events, func(t *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		if txResponse.Height < from || checkpoint.replayed(txResponse.Height, txResponse.TxHash) {
+			return nil
+		}
+		sqlEvents, err := newSQLEvents(t.GetMsgs(), txResponse)
+		if err != nil {
+			return err
+		}
+		var changes []*Change
+		for _, e := range sqlEvents {
+			if r.filter.Match(e) {
+				changes = append(changes, newChange(e))
+			}
+		}
+		checkpoint.advance(txResponse.Height, txResponse.TxHash)
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := sink.Write(changes); err != nil {
+			return sdkerrors.Wrap(err, "failed to write changes")
+		}
+		return r.saveCheckpoint(checkpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.saveCheckpoint(checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint.copy(), nil
+}
+
+func (r *CDCReader) saveCheckpoint(checkpoint *Checkpoint) error {
+	if r.checkpoints == nil {
+		return nil
+	}
+	if err := r.checkpoints.SaveCheckpoint(checkpoint); err != nil {
+		return sdkerrors.Wrap(err, "failed to save checkpoint")
+	}
+	return nil
+}
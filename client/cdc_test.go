@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CDCReader(t *testing.T) {
+	search := &txSearchServer{t: t, lcd: New("glitter_12000-2", nil)}
+	search.commitSQL(3, "create table library.ebook (id int)", "insert into library.author values (1)")
+	a5 := search.commitSQL(5, "insert into library.ebook values (1)", "update `library`.`ebook` set id=2")
+	srv := httptest.NewServer(search)
+	defer srv.Close()
+
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	checkpoints := &MemoryCheckpointStore{}
+	reader, err := lcd.NewCDCReader(SQLEventFilter{
+		Database:       "library",
+		Table:          "ebook",
+		StatementTypes: []string{"INSERT", "UPDATE", "DELETE"},
+	}, checkpoints)
+	require.NoError(t, err)
+
+	sink := &MemorySink{}
+	checkpoint, err := reader.Run(context.Background(), sink)
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 5, TxHashes: []string{a5}}, checkpoint)
+	changes := sink.Changes()
+	require.Len(t, changes, 2)
+	assert.Equal(t, "insert into library.ebook values (1)", changes[0].SQL)
+	assert.Equal(t, "UPDATE", changes[1].StatementType)
+	assert.Equal(t, 1, changes[1].MsgIndex)
+	assert.Equal(t, a5, changes[1].TxHash)
+	assert.Equal(t, int64(5), changes[1].Height)
+	assert.Equal(t, testSigner, changes[1].Signer)
+	assert.Equal(t, "2023-08-26T08:01:43Z", changes[1].Time.UTC().Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, "1", changes[1].Arguments[0].Value)
+
+	// resumes after the checkpoint, including the later txs of its height
+	b5 := search.commitSQL(5, "delete from library.ebook where id=2")
+	exec := authz.NewMsgExec(testAddress(1), testSQLMsgs("insert into library.ebook values (4)"))
+	e6 := search.commitMsgs(6, &exec)
+	a7 := search.commitSQL(7, "insert into library.ebook values (3)")
+	_, err = reader.Run(context.Background(), sink)
+	require.NoError(t, err)
+	changes = sink.Changes()
+	require.Len(t, changes, 5)
+	assert.Equal(t, b5, changes[2].TxHash)
+	// the SQL executed on behalf of a granter
+	assert.Equal(t, e6, changes[3].TxHash)
+	assert.Equal(t, "insert into library.ebook values (4)", changes[3].SQL)
+	assert.Equal(t, a7, changes[4].TxHash)
+
+	checkpoint, err = reader.Run(context.Background(), sink)
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 7, TxHashes: []string{a7}}, checkpoint)
+	assert.Len(t, sink.Changes(), 5)
+}
+
+func Test_CDCFileSinks(t *testing.T) {
+	dir := t.TempDir()
+	store := &FileCheckpointStore{Path: filepath.Join(dir, "checkpoint.json")}
+	checkpoint, err := store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+	require.NoError(t, store.SaveCheckpoint(&Checkpoint{Height: 9, TxHashes: []string{"A9"}}))
+	checkpoint, err = store.LoadCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 9, TxHashes: []string{"A9"}}, checkpoint)
+
+	path := filepath.Join(dir, "changes.jsonl")
+	for _, sql := range []string{"insert into db.t values (1)", "delete from db.t"} {
+		sink, err := NewJSONLSink(path)
+		require.NoError(t, err)
+		require.NoError(t, sink.Write([]*Change{{Height: 9, TxHash: "A9", Table: "t", SQL: sql}}))
+		require.NoError(t, sink.Close())
+	}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var changes []*Change
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var c Change
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &c))
+		changes = append(changes, &c)
+	}
+	require.Len(t, changes, 2)
+	assert.Equal(t, "insert into db.t values (1)", changes[0].SQL)
+	assert.Equal(t, "delete from db.t", changes[1].SQL)
+}
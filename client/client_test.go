@@ -0,0 +1,21 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCreateMnemonic(t *testing.T) string {
+	mnemonic, err := key.CreateMnemonic()
+	require.NoError(t, err)
+	return mnemonic
+}
+
+// newTestClient create client of glitter_12000-2 signing with the key of a new mnemonic
+func newTestClient(t *testing.T, opts ...Option) *LCDClient {
+	privKey, err := key.PrivKeyGenByMnemonic(mustCreateMnemonic(t), key.CreateHDPath(0, 0))
+	require.NoError(t, err)
+	return New("glitter_12000-2", privKey, opts...)
+}
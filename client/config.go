@@ -0,0 +1,287 @@
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v3"
+)
+
+// names of the built-in network profiles
+const (
+	NetworkMainnet = "mainnet"
+	NetworkTestnet = "testnet"
+	NetworkLocal   = "local"
+)
+
+// DefaultNetwork network profile used when neither the config file nor GLITTER_PROFILE selects one
+const DefaultNetwork = NetworkMainnet
+
+// Profile settings of a chain network and, optionally, of the key signing txs on it
+type Profile struct {
+	// Name name of the profile, set by Config.Profile
+	Name string `yaml:"-" toml:"-"`
+	// Network built-in network profile the unset fields default to, default to the profile name
+	// when it is a built-in network
+	Network string `yaml:"network" toml:"network"`
+
+	ChainID       string `yaml:"chain_id" toml:"chain_id"`
+	REST          string `yaml:"rest" toml:"rest"`
+	RPC           string `yaml:"rpc" toml:"rpc"`
+	GasPrice      string `yaml:"gas_price" toml:"gas_price"`
+	GasAdjustment string `yaml:"gas_adjustment" toml:"gas_adjustment"`
+	Denom         string `yaml:"denom" toml:"denom"`
+	Bech32Prefix  string `yaml:"bech32_prefix" toml:"bech32_prefix"`
+	// Timeout http timeout, such as 10s
+	Timeout    string `yaml:"timeout" toml:"timeout"`
+	FeeGranter string `yaml:"fee_granter" toml:"fee_granter"`
+
+	// Mnemonic mnemonic of the key signing txs, the client is query only when it is empty
+	Mnemonic  string `yaml:"mnemonic" toml:"mnemonic"`
+	HDAccount uint32 `yaml:"hd_account" toml:"hd_account"`
+	HDIndex   uint32 `yaml:"hd_index" toml:"hd_index"`
+}
+
+// Networks built-in network profiles, the endpoints of testnet are not bundled
+// and must be set by the config file or GLITTER_* env, the chain id of testnet and local
+// is detected from the node unless set
+var Networks = map[string]Profile{
+	NetworkMainnet: {
+		ChainID:       "glitter_12000-2",
+		REST:          DefaultChainEndpoint,
+		GasPrice:      "1",
+		GasAdjustment: "2.5",
+		Denom:         "agli",
+		Bech32Prefix:  msg.GlitterAccountPrefix,
+	},
+	NetworkTestnet: {
+		GasPrice:      "1",
+		GasAdjustment: "2.5",
+		Denom:         "agli",
+		Bech32Prefix:  msg.GlitterAccountPrefix,
+	},
+	NetworkLocal: {
+		REST:          "http://127.0.0.1:1317",
+		RPC:           "http://127.0.0.1:26657",
+		GasPrice:      "1",
+		GasAdjustment: "2.5",
+		Denom:         "agli",
+		Bech32Prefix:  msg.GlitterAccountPrefix,
+	},
+}
+
+// Config content of a YAML or TOML config file:
+//
+//	profile: ops
+//	profiles:
+//	  ops:
+//	    network: testnet
+//	    chain_id: glitter_12000-2
+//	    rest: https://rest.example.com
+//	    mnemonic: ...
+type Config struct {
+	// DefaultProfile profile used when neither Config.Profile nor GLITTER_PROFILE names one
+	DefaultProfile string             `yaml:"profile" toml:"profile"`
+	Profiles       map[string]Profile `yaml:"profiles" toml:"profiles"`
+}
+
+// LoadConfig read the config file of path, a YAML file unless its extension is .toml,
+// an empty path is a config of the built-in networks only
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if len(path) == 0 {
+		return cfg, nil
+	}
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(bz, cfg)
+	default:
+		err = yaml.Unmarshal(bz, cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Profile returns the named profile with its unset fields defaulted to its network
+// and overridden by the GLITTER_* env
+// Args:
+//   - name: name of the profile, default to GLITTER_PROFILE, the profile of the config file or mainnet
+//
+// Returns:
+//
+//	the resolved profile
+func (c *Config) Profile(name string) (*Profile, error) {
+	name = utils.FirstNonEmpty(name, os.Getenv("GLITTER_PROFILE"), c.DefaultProfile, DefaultNetwork)
+
+	p, ok := c.Profiles[name]
+	if _, builtin := Networks[name]; !ok && !builtin {
+		return nil, fmt.Errorf("profile %s not found", name)
+	}
+	p.Name = name
+	network := utils.FirstNonEmpty(p.Network, name)
+	base, ok := Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("profile %s: unknown network %s", name, network)
+	}
+	p.Network = network
+	p.merge(base)
+	if err := p.applyEnv(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// merge set the unset fields of p from base
+func (p *Profile) merge(base Profile) {
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&p.ChainID, base.ChainID},
+		{&p.REST, base.REST},
+		{&p.RPC, base.RPC},
+		{&p.GasPrice, base.GasPrice},
+		{&p.GasAdjustment, base.GasAdjustment},
+		{&p.Denom, base.Denom},
+		{&p.Bech32Prefix, base.Bech32Prefix},
+		{&p.Timeout, base.Timeout},
+		{&p.FeeGranter, base.FeeGranter},
+	}
+	for _, f := range fields {
+		if len(*f.dst) == 0 {
+			*f.dst = f.src
+		}
+	}
+}
+
+// applyEnv override the fields of p by the set GLITTER_* env
+func (p *Profile) applyEnv() error {
+	strs := []struct {
+		dst *string
+		env []string
+	}{
+		{&p.ChainID, []string{"GLITTER_CHAIN_ID"}},
+		{&p.REST, []string{"GLITTER_REST", "GLITTER_ENDPOINT"}},
+		{&p.RPC, []string{"GLITTER_RPC"}},
+		{&p.GasPrice, []string{"GLITTER_GAS_PRICE"}},
+		{&p.GasAdjustment, []string{"GLITTER_GAS_ADJUSTMENT"}},
+		{&p.Denom, []string{"GLITTER_DENOM"}},
+		{&p.Bech32Prefix, []string{"GLITTER_BECH32_PREFIX"}},
+		{&p.Timeout, []string{"GLITTER_TIMEOUT"}},
+		{&p.FeeGranter, []string{"GLITTER_FEE_GRANTER"}},
+		{&p.Mnemonic, []string{"GLITTER_MNEMONIC"}},
+	}
+	for _, s := range strs {
+		for _, env := range s.env {
+			if v := os.Getenv(env); len(v) > 0 {
+				*s.dst = v
+				break
+			}
+		}
+	}
+	uints := []struct {
+		dst *uint32
+		env string
+	}{
+		{&p.HDAccount, "GLITTER_HD_ACCOUNT"},
+		{&p.HDIndex, "GLITTER_HD_INDEX"},
+	}
+	for _, u := range uints {
+		v := os.Getenv(u.env)
+		if len(v) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", u.env, v)
+		}
+		*u.dst = uint32(n)
+	}
+	return nil
+}
+
+// Options returns the client options of the profile
+func (p *Profile) Options() ([]Option, error) {
+	if len(p.REST) == 0 {
+		return nil, fmt.Errorf("profile %s: rest endpoint is not set, set rest in the config file or GLITTER_REST", p.Name)
+	}
+	codec := msg.NewBech32Codec(utils.FirstNonEmpty(p.Bech32Prefix, msg.GlitterAccountPrefix))
+	options := []Option{WithChainEndpoint(p.REST), WithBech32Prefix(codec.AccountPrefix)}
+	if len(p.RPC) > 0 {
+		options = append(options, WithRPCEndpoint(p.RPC))
+	}
+
+	gasPrice, err := msg.NewDecFromStr(utils.FirstNonEmpty(p.GasPrice, "1"))
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: invalid gas_price %q: %w", p.Name, p.GasPrice, err)
+	}
+	gasAdjustment, err := msg.NewDecFromStr(utils.FirstNonEmpty(p.GasAdjustment, "2.5"))
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: invalid gas_adjustment %q: %w", p.Name, p.GasAdjustment, err)
+	}
+	options = append(options, WithGasFeeConfig(msg.NewDecCoinFromDec(utils.FirstNonEmpty(p.Denom, "agli"), gasPrice), gasAdjustment))
+
+	if len(p.Timeout) > 0 {
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: invalid timeout %q: %w", p.Name, p.Timeout, err)
+		}
+		options = append(options, WithTimeout(timeout))
+	}
+	if len(p.FeeGranter) > 0 {
+		granter, err := codec.AccAddressFromBech32(p.FeeGranter)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: invalid fee_granter %q: %w", p.Name, p.FeeGranter, err)
+		}
+		options = append(options, WithFeeGranter(granter))
+	}
+	return options, nil
+}
+
+// PrivKey returns the key derived from the mnemonic of the profile, nil when the mnemonic is empty
+func (p *Profile) PrivKey() (key.PrivKey, error) {
+	if len(p.Mnemonic) == 0 {
+		return nil, nil
+	}
+	return key.PrivKeyGenByMnemonic(p.Mnemonic, key.CreateHDPath(p.HDAccount, p.HDIndex))
+}
+
+// NewFromConfig create client of the network and the key of profile
+// Args:
+//   - profile: profile resolved by Config.Profile
+//   - options: options applied after the ones of profile
+//
+// Returns:
+//
+//	client configured by profile, query only when the profile has no mnemonic,
+//	the chain id is detected from the node when the profile has none
+func NewFromConfig(profile *Profile, options ...Option) (*LCDClient, error) {
+	profileOptions, err := profile.Options()
+	if err != nil {
+		return nil, err
+	}
+	if len(profile.ChainID) == 0 {
+		// detect the chain id by the handshake before the first tx
+		profileOptions = append(profileOptions, WithHandshake(NodeRequirements{}))
+	}
+	privKey, err := profile.PrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
+	return New(profile.ChainID, privKey, append(profileOptions, options...)...), nil
+}
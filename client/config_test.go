@@ -0,0 +1,105 @@
+package client
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(yamlPath, []byte(`
+profile: ops
+profiles:
+  ops:
+    network: local
+    chain_id: glitter_1-1
+    gas_price: "2"
+    timeout: 3s
+`), 0600))
+	tomlPath := filepath.Join(dir, "config.toml")
+	require.NoError(t, ioutil.WriteFile(tomlPath, []byte(`
+profile = "ops"
+
+[profiles.ops]
+network = "local"
+chain_id = "glitter_1-1"
+gas_price = "2"
+timeout = "3s"
+`), 0600))
+
+	for _, path := range []string{yamlPath, tomlPath} {
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err, path)
+		p, err := cfg.Profile("")
+		require.NoError(t, err, path)
+		assert.Equal(t, "ops", p.Name)
+		assert.Equal(t, NetworkLocal, p.Network)
+		assert.Equal(t, "glitter_1-1", p.ChainID)
+		assert.Equal(t, "http://127.0.0.1:1317", p.REST)
+		assert.Equal(t, "http://127.0.0.1:26657", p.RPC)
+		assert.Equal(t, "2", p.GasPrice)
+		assert.Equal(t, "agli", p.Denom)
+		assert.Equal(t, "glitter", p.Bech32Prefix)
+	}
+}
+
+func Test_ConfigProfile(t *testing.T) {
+	cfg := &Config{}
+
+	p, err := cfg.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, NetworkMainnet, p.Name)
+	assert.Equal(t, DefaultChainEndpoint, p.REST)
+
+	_, err = cfg.Profile("unknown")
+	assert.Error(t, err)
+
+	t.Setenv("GLITTER_PROFILE", NetworkLocal)
+	t.Setenv("GLITTER_ENDPOINT", "http://node:1317")
+	t.Setenv("GLITTER_HD_INDEX", "3")
+	p, err = cfg.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, NetworkLocal, p.Name)
+	assert.Equal(t, "http://node:1317", p.REST)
+	assert.Equal(t, uint32(3), p.HDIndex)
+
+	t.Setenv("GLITTER_HD_INDEX", "x")
+	_, err = cfg.Profile("")
+	assert.Error(t, err)
+}
+
+func Test_NewFromConfig(t *testing.T) {
+	testnet, err := (&Config{}).Profile(NetworkTestnet)
+	require.NoError(t, err)
+	_, err = NewFromConfig(testnet)
+	assert.Error(t, err, "testnet endpoints are not bundled")
+
+	p, err := (&Config{}).Profile(NetworkLocal)
+	require.NoError(t, err)
+	p.GasPrice = "2"
+	p.Timeout = "3s"
+	lcd, err := NewFromConfig(p)
+	require.NoError(t, err)
+	assert.Empty(t, lcd.ChainID, "detected by the handshake")
+	assert.NotNil(t, lcd.handshake)
+	assert.Equal(t, "http://127.0.0.1:1317", lcd.URL)
+	assert.Equal(t, "http://127.0.0.1:26657", lcd.RPCURL)
+	assert.Equal(t, "2.000000000000000000agli", lcd.GasPrice.String())
+	assert.Equal(t, 3*time.Second, lcd.c.Timeout)
+	assert.Nil(t, lcd.PrivKey)
+
+	p.FeeGranter = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	_, err = NewFromConfig(p)
+	assert.Error(t, err, "fee granter of another prefix")
+
+	p.FeeGranter = ""
+	p.Mnemonic = "sea vote bike wage fiction coral hold quote gown steak fresh hockey"
+	_, err = NewFromConfig(p)
+	assert.Error(t, err, "invalid mnemonic")
+}
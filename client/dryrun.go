@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// DryRunResult result of simulating a tx without broadcasting it
+type DryRunResult struct {
+	// GasUsed simulated gas used
+	GasUsed uint64
+	// GasLimit gas limit the tx would be signed with
+	GasLimit uint64
+	// Fee fee the tx would pay
+	Fee msg.Coins
+	// Err SQL, permission or other error of the simulated ante handler or msg handler
+	Err error
+	// Result simulated result, nil if Err is set
+	Result *sdk.Result
+	// Responses decoded SQLExecResponse of every SQLExecRequest of the tx
+	Responses []*glittertypes.SQLExecResponse
+}
+
+// TxResponse returns the dry run as a tx response without hash and height
+func (r *DryRunResult) TxResponse() *sdk.TxResponse {
+	txResponse := &sdk.TxResponse{
+		GasWanted: int64(r.GasLimit),
+		GasUsed:   int64(r.GasUsed),
+	}
+	if r.Err != nil {
+		txResponse.Code = sdkerrors.ErrUnknownRequest.ABCICode()
+		txResponse.RawLog = r.Err.Error()
+	}
+	if r.Result != nil {
+		txResponse.Data = hex.EncodeToString(r.Result.Data)
+		txResponse.RawLog = r.Result.Log
+		txResponse.Logs = sdk.ABCIMessageLogs{{Log: r.Result.Log, Events: sdk.StringifyEvents(r.Result.Events)}}
+	}
+	return txResponse
+}
+
+// SQLExecDryRun Validate a SQL without broadcasting or paying for it
+// Args:
+//   - sql: SQL statement to execute
+//   - args: Parameters of the SQL statement, default to None
+//
+// Returns:
+// The estimated gas and fee, the SQL or permission error and the decoded SQLExecResponse of the simulation
+func (lcd *LCDClient) SQLExecDryRun(ctx context.Context, sql string, args []*glittertypes.Argument) (*DryRunResult, error) {
+	_msg := lcd.newSQLExecRequest(sql, args)
+	return lcd.DryRunTx(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
+
+// DryRunTx Build the tx of options and simulate it without broadcasting
+// Args:
+//   - options: Tx options, GasLimit and FeeAmount are used as is if set
+//
+// Returns:
+// The simulated result, rejections of the simulated tx are returned in Err of the result
+func (lcd *LCDClient) DryRunTx(ctx context.Context, options CreateTxOptions) (*DryRunResult, error) {
+	txbuilder, err := lcd.prepareTx(ctx, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DryRunResult{GasLimit: options.GasLimit}
+	simulateRes, err := lcd.Simulate(ctx, txbuilder, options)
+	var simulateErr *SimulateError
+	switch {
+	case errors.As(err, &simulateErr):
+		res.Err = simulateErr
+	case err != nil:
+		return nil, sdkerrors.Wrap(err, "failed to simulate")
+	default:
+		res.GasUsed = simulateRes.GasInfo.GasUsed
+		res.Result = simulateRes.Result
+		if res.GasLimit == 0 {
+			res.GasLimit = uint64(lcd.GasAdjustment.MulInt64(int64(res.GasUsed)).TruncateInt64())
+		}
+		if res.Result != nil {
+			res.Responses, err = decodeSQLExecResponses(res.Result.Data)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := options.checkMaxGas(res.GasLimit); err != nil {
+		return nil, err
+	}
+
+	res.Fee = options.FeeAmount
+	if res.Fee.IsZero() {
+		gasPrice, err := lcd.currentGasPrice(ctx)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to estimate gas price")
+		}
+		res.Fee = calculateFee(gasPrice, int64(res.GasLimit))
+	}
+	return res, nil
+}
+
+// decodeSQLExecResponses decode the SQLExecResponse of every SQLExecRequest from the TxMsgData of a tx
+func decodeSQLExecResponses(data []byte) ([]*glittertypes.SQLExecResponse, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var txMsgData sdk.TxMsgData
+	if err := txMsgData.Unmarshal(data); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx msg data")
+	}
+
+	var responses []*glittertypes.SQLExecResponse
+	for _, msgData := range txMsgData.Data {
+		if msgData.MsgType != SQLExecMsgTypeURL {
+			continue
+		}
+		var response glittertypes.SQLExecResponse
+		if err := proto.Unmarshal(msgData.Data, &response); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to decode sql exec response")
+		}
+		responses = append(responses, &response)
+	}
+	return responses, nil
+}
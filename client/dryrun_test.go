@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DryRun(t *testing.T) {
+
+	var simulated []byte
+	rejectStatus, rejectBody := 0, ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/tx/v1beta1/simulate" {
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+		if rejectStatus != 0 {
+			w.WriteHeader(rejectStatus)
+			w.Write([]byte(rejectBody))
+			return
+		}
+		w.Write(simulated)
+	}))
+	defer srv.Close()
+
+	lcd := newTestClient(t, WithChainEndpoint(srv.URL), WithDryRun(true), WithFixedGasPrice())
+	responseData, err := proto.Marshal(&glittertypes.SQLExecResponse{})
+	assert.NoError(t, err)
+	txMsgData, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{{MsgType: SQLExecMsgTypeURL, Data: responseData}}})
+	assert.NoError(t, err)
+	simulated, err = lcd.GetMarshaler().MarshalJSON(&sdktx.SimulateResponse{
+		GasInfo: &sdk.GasInfo{GasUsed: 1000},
+		Result:  &sdk.Result{Data: txMsgData},
+	})
+	assert.NoError(t, err)
+
+	options := CreateTxOptions{AccountNumber: 1, Sequence: 1, SignMode: tx.SignModeDirect}
+	txResponse, err := lcd.SQLExecWithOptions(context.Background(), options, "insert into db.t (a) values (1)", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), txResponse.GasWanted)
+	assert.Empty(t, txResponse.TxHash)
+
+	// the write helpers returning the result sign with the options of the caller
+	withGas := options
+	withGas.GasLimit = 3000
+	txResponse, execRes, err := lcd.InsertResult(context.Background(), withGas, "db", "t", map[string]interface{}{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), txResponse.GasWanted)
+	assert.Len(t, execRes.Responses, 1)
+
+	options.Msgs = []sdk.Msg{glittertypes.NewSQLExecRequest(lcd.GetAddress(), "insert into db.t (a) values (1)", nil)}
+	res, err := lcd.DryRunTx(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), res.GasUsed)
+	assert.Equal(t, "2500agli", res.Fee.String())
+	assert.Len(t, res.Responses, 1)
+
+	// the sdk rejects a tx with code Unknown, served as status 500
+	rejectStatus, rejectBody = http.StatusInternalServerError, `{"code":2,"message":"permission denied: unknown request With gas wanted: '0' and gas used: '1000' ","details":[]}`
+	res, err = lcd.DryRunTx(context.Background(), options)
+	assert.NoError(t, err)
+	assert.Equal(t, "permission denied: unknown request With gas wanted: '0' and gas used: '1000' ", res.Err.(*SimulateError).Message)
+
+	rejectStatus, rejectBody = http.StatusBadRequest, `{"code":3,"message":"invalid tx; tx parse error","details":[]}`
+	res, err = lcd.DryRunTx(context.Background(), options)
+	assert.NoError(t, err)
+	assert.EqualError(t, res.Err, `non-200 response code 400: {"code":3,"message":"invalid tx; tx parse error","details":[]}`)
+
+	txResponse, err = lcd.SQLExecWithOptions(context.Background(), options, "insert into db.t (a) values (1)", nil)
+	assert.Error(t, err)
+	assert.NotZero(t, txResponse.Code)
+
+	// failures of the node are errors of the dry run, not rejections of the tx
+	for status, body := range map[int]string{
+		http.StatusNotFound:            `{"code":5,"message":"Not Found","details":[]}`,
+		http.StatusInternalServerError: `{"code":13,"message":"internal error","details":[]}`,
+		http.StatusBadGateway:          `<html>bad gateway</html>`,
+	} {
+		rejectStatus, rejectBody = status, body
+		_, err = lcd.DryRunTx(context.Background(), options)
+		assert.Error(t, err)
+		var simulateErr *SimulateError
+		assert.False(t, errors.As(err, &simulateErr))
+	}
+}
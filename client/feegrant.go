@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// SQLExecMsgTypeURL type url of the glitter SQLExecRequest message
+var SQLExecMsgTypeURL = sdk.MsgTypeURL(&glittertypes.SQLExecRequest{})
+
+// NewBasicAllowance create fee allowance of up to spendLimit until expiration
+// Args:
+//   - spendLimit: Max fee the grantee can spend, unlimited if empty
+//   - expiration: Time the allowance expires, never if nil
+func NewBasicAllowance(spendLimit msg.Coins, expiration *time.Time) *feegrant.BasicAllowance {
+	return &feegrant.BasicAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// NewPeriodicAllowance create fee allowance of up to periodSpendLimit every period, within the limits of basic
+// Args:
+//   - basic: Overall spend limit and expiration
+//   - period: Duration after which the period spend limit is reset
+//   - periodSpendLimit: Max fee the grantee can spend in one period
+func NewPeriodicAllowance(basic *feegrant.BasicAllowance, period time.Duration, periodSpendLimit msg.Coins) *feegrant.PeriodicAllowance {
+	return &feegrant.PeriodicAllowance{
+		Basic:            *basic,
+		Period:           period,
+		PeriodSpendLimit: periodSpendLimit,
+		PeriodCanSpend:   periodSpendLimit,
+		PeriodReset:      time.Now().Add(period),
+	}
+}
+
+// NewSQLExecAllowance restrict allowance to pay for glitter SQLExecRequest messages only
+func NewSQLExecAllowance(allowance feegrant.FeeAllowanceI) (*feegrant.AllowedMsgAllowance, error) {
+	return feegrant.NewAllowedMsgAllowance(allowance, []string{SQLExecMsgTypeURL})
+}
+
+// GrantFeeAllowance Allow grantee to pay tx fees from the client account
+// Args:
+//   - grantee: Address allowed to use the allowance
+//   - allowance: Basic, periodic or allowed msg allowance
+//
+// Returns:
+// Result of broadcasting grant transaction
+func (lcd *LCDClient) GrantFeeAllowance(ctx context.Context, grantee msg.AccAddress, allowance feegrant.FeeAllowanceI) (*sdk.TxResponse, error) {
+	_msg, err := lcd.newGrantAllowanceMsg(grantee, allowance)
+	if err != nil {
+		return nil, err
+	}
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{_msg}})
+}
+
+func (lcd *LCDClient) newGrantAllowanceMsg(grantee msg.AccAddress, allowance feegrant.FeeAllowanceI) (*feegrant.MsgGrantAllowance, error) {
+	m, ok := allowance.(proto.Message)
+	if !ok {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrPackAny, "cannot proto marshal %T", allowance)
+	}
+	any, err := cdctypes.NewAnyWithValue(m)
+	if err != nil {
+		return nil, err
+	}
+	return &feegrant.MsgGrantAllowance{Granter: lcd.Bech32Address(), Grantee: lcd.bech32(grantee), Allowance: any}, nil
+}
+
+// RevokeFeeAllowance Revoke the fee allowance granted to grantee by the client account
+// Args:
+//   - grantee: Address of the allowance grantee
+//
+// Returns:
+// Result of broadcasting revoke transaction
+func (lcd *LCDClient) RevokeFeeAllowance(ctx context.Context, grantee msg.AccAddress) (*sdk.TxResponse, error) {
+	return lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: []msg.Msg{lcd.newRevokeAllowanceMsg(grantee)}})
+}
+
+func (lcd *LCDClient) newRevokeAllowanceMsg(grantee msg.AccAddress) *feegrant.MsgRevokeAllowance {
+	return &feegrant.MsgRevokeAllowance{Granter: lcd.Bech32Address(), Grantee: lcd.bech32(grantee)}
+}
+
+// FeeAllowance Query the fee allowance granted by granter to grantee
+// Args:
+//   - granter: Address paying the fees
+//   - grantee: Address using the allowance
+//
+// Returns:
+// The fee grant, the allowance can be read by Grant.GetGrant()
+func (lcd *LCDClient) FeeAllowance(ctx context.Context, granter, grantee msg.AccAddress) (*feegrant.Grant, error) {
+	var response feegrant.QueryAllowanceResponse
+	err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/feegrant/v1beta1/allowance/%s/%s", lcd.bech32(granter), lcd.bech32(grantee)), &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Allowance == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrNotFound, "no fee allowance")
+	}
+	// the query response of the sdk does not unpack the allowance
+	if err := response.Allowance.UnpackInterfaces(lcd.EncodingConfig.InterfaceRegistry); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to unpack fee allowance")
+	}
+	return response.Allowance, nil
+}
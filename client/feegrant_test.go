@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAllowance a fee allowance that is not a proto message
+type fakeAllowance struct{}
+
+func (fakeAllowance) Accept(sdk.Context, sdk.Coins, []sdk.Msg) (bool, error) { return false, nil }
+func (fakeAllowance) ValidateBasic() error                                   { return nil }
+
+func Test_FeeAllowances(t *testing.T) {
+	limit := msg.NewCoins(msg.NewInt64Coin("agli", 100))
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	basic := NewBasicAllowance(limit, &expiration)
+	assert.NoError(t, basic.ValidateBasic())
+	assert.Equal(t, limit, basic.SpendLimit)
+	assert.Equal(t, &expiration, basic.Expiration)
+	assert.Nil(t, NewBasicAllowance(nil, nil).Expiration)
+
+	periodLimit := msg.NewCoins(msg.NewInt64Coin("agli", 10))
+	before := time.Now()
+	periodic := NewPeriodicAllowance(basic, time.Hour, periodLimit)
+	assert.NoError(t, periodic.ValidateBasic())
+	assert.Equal(t, *basic, periodic.Basic)
+	assert.Equal(t, time.Hour, periodic.Period)
+	assert.Equal(t, periodLimit, periodic.PeriodSpendLimit)
+	assert.Equal(t, periodLimit, periodic.PeriodCanSpend)
+	assert.False(t, periodic.PeriodReset.Before(before.Add(time.Hour)))
+
+	allowed, err := NewSQLExecAllowance(periodic)
+	require.NoError(t, err)
+	assert.NoError(t, allowed.ValidateBasic())
+	assert.Equal(t, []string{SQLExecMsgTypeURL}, allowed.AllowedMessages)
+	inner, err := allowed.GetAllowance()
+	require.NoError(t, err)
+	assert.Equal(t, periodic, inner)
+}
+
+func Test_FeeGrantMsgs(t *testing.T) {
+	lcd := newTestClient(t)
+	grantee := msg.AccAddress(make([]byte, 20))
+
+	allowance := NewBasicAllowance(msg.NewCoins(msg.NewInt64Coin("agli", 100)), nil)
+	grant, err := lcd.newGrantAllowanceMsg(grantee, allowance)
+	require.NoError(t, err)
+	assert.Equal(t, lcd.Bech32Address(), grant.Granter)
+	assert.Equal(t, msg.GlitterBech32Codec.AccAddressString(grantee), grant.Grantee)
+	assert.Equal(t, "/cosmos.feegrant.v1beta1.BasicAllowance", grant.Allowance.TypeUrl)
+	packed, err := grant.GetFeeAllowanceI()
+	require.NoError(t, err)
+	assert.Equal(t, allowance, packed)
+
+	_, err = lcd.newGrantAllowanceMsg(grantee, fakeAllowance{})
+	assert.Error(t, err)
+
+	revoke := lcd.newRevokeAllowanceMsg(grantee)
+	assert.Equal(t, lcd.Bech32Address(), revoke.Granter)
+	assert.Equal(t, msg.GlitterBech32Codec.AccAddressString(grantee), revoke.Grantee)
+}
+
+func Test_WithFeeGranter(t *testing.T) {
+	granter := msg.AccAddress(make([]byte, 20))
+	granter[0] = 1
+	from := msg.AccAddress(make([]byte, 20))
+
+	for _, lcd := range []*LCDClient{New("glitter_12000-2", nil), New("glitter_12000-2", nil, WithFeeGranter(granter))} {
+		unsigned, err := lcd.GenerateTx(CreateTxOptions{
+			Msgs:     []msg.Msg{msg.NewMsgSend(from, from, msg.NewCoins(msg.NewInt64Coin("agli", 1)))},
+			GasLimit: 200000,
+		})
+		require.NoError(t, err)
+		txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), unsigned)
+		require.NoError(t, err)
+		// FeeGranter of the sdk decodes by the global config, read the encoded granter instead
+		protoTx, ok := txbuilder.GetTx().(interface{ GetProtoTx() *txtypes.Tx })
+		require.True(t, ok)
+		assert.Equal(t, lcd.bech32(lcd.FeeGranter), protoTx.GetProtoTx().AuthInfo.Fee.Granter)
+	}
+}
+
+func Test_FeeAllowance(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	granter, grantee := msg.AccAddress(make([]byte, 20)), msg.AccAddress(make([]byte, 20))
+	grantee[0] = 1
+	grant, err := feegrant.NewGrant(granter, grantee, NewBasicAllowance(msg.NewCoins(msg.NewInt64Coin("agli", 100)), nil))
+	require.NoError(t, err)
+	grant.Granter, grant.Grantee = lcd.bech32(granter), lcd.bech32(grantee)
+	body, err := lcd.GetMarshaler().MarshalJSON(&feegrant.QueryAllowanceResponse{Allowance: &grant})
+	require.NoError(t, err)
+
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	res, err := lcd.FeeAllowance(context.Background(), granter, grantee)
+	require.NoError(t, err)
+	assert.Equal(t, "/cosmos/feegrant/v1beta1/allowance/"+lcd.bech32(granter)+"/"+lcd.bech32(grantee), path)
+	assert.Equal(t, lcd.bech32(grantee), res.Grantee)
+	allowance, err := res.GetGrant()
+	require.NoError(t, err)
+	assert.Equal(t, msg.NewCoins(msg.NewInt64Coin("agli", 100)), allowance.(*feegrant.BasicAllowance).SpendLimit)
+}
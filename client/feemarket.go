@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	feemarkettypes "github.com/evmos/ethermint/x/feemarket/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+)
+
+// DefaultFeeEstimateTTL how long an estimated gas price is reused
+const DefaultFeeEstimateTTL = time.Second * 6
+
+// TipPolicy returns the priority tip paid on top of the base fee
+type TipPolicy func(baseFee msg.Dec) msg.Dec
+
+// FixedTip tip a fixed amount per gas
+func FixedTip(tip msg.Dec) TipPolicy {
+	return func(msg.Dec) msg.Dec {
+		return tip
+	}
+}
+
+// PercentTip tip a percentage of the base fee, 0.1 tips 10%
+func PercentTip(percent msg.Dec) TipPolicy {
+	return func(baseFee msg.Dec) msg.Dec {
+		return baseFee.Mul(percent)
+	}
+}
+
+// FeeEstimator estimates the gas price from the x/feemarket base fee
+type FeeEstimator struct {
+	lcd *LCDClient
+	tip TipPolicy
+	ttl time.Duration
+
+	mu        sync.Mutex
+	gasPrice  msg.DecCoin
+	expiresAt time.Time
+}
+
+// NewFeeEstimator create fee estimator of lcd, the estimated price is cached for ttl
+func NewFeeEstimator(lcd *LCDClient, tip TipPolicy, ttl time.Duration) *FeeEstimator {
+	if tip == nil {
+		tip = FixedTip(sdk.ZeroDec())
+	}
+	return &FeeEstimator{
+		lcd: lcd,
+		tip: tip,
+		ttl: ttl,
+	}
+}
+
+// GasPrice returns base fee plus tip, never lower than the feemarket min gas price or the client gas price.
+// The client gas price is used when the base fee is disabled or the node has no x/feemarket.
+// The node is queried without holding the cache lock, concurrent callers on a cache miss query it each
+// with their own ctx.
+func (e *FeeEstimator) GasPrice(ctx context.Context) (msg.DecCoin, error) {
+	e.mu.Lock()
+	if time.Now().Before(e.expiresAt) {
+		gasPrice := e.gasPrice
+		e.mu.Unlock()
+		return gasPrice, nil
+	}
+	e.mu.Unlock()
+
+	price := e.lcd.GasPrice.Amount
+	params, err := e.lcd.FeeMarketParams(ctx)
+	if err != nil && !errors.Is(err, sdkerrors.ErrNotFound) {
+		return msg.DecCoin{}, err
+	}
+	if err == nil {
+		if !params.NoBaseFee {
+			baseFee, err := e.lcd.BaseFee(ctx)
+			if err != nil {
+				return msg.DecCoin{}, err
+			}
+			baseFeeDec := sdk.NewDecFromInt(baseFee)
+			price = sdk.MaxDec(price, baseFeeDec.Add(e.tip(baseFeeDec)))
+		}
+		if !params.MinGasPrice.IsNil() {
+			price = sdk.MaxDec(price, params.MinGasPrice)
+		}
+	}
+
+	gasPrice := msg.NewDecCoinFromDec(e.lcd.GasPrice.Denom, price)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.gasPrice = gasPrice
+	e.expiresAt = time.Now().Add(e.ttl)
+	return gasPrice, nil
+}
+
+// lastGasPrice returns the last estimated gas price even if expired, the client gas price if none is estimated
+func (e *FeeEstimator) lastGasPrice() msg.DecCoin {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.gasPrice.Denom != e.lcd.GasPrice.Denom || e.gasPrice.Amount.IsNil() || e.gasPrice.Amount.LT(e.lcd.GasPrice.Amount) {
+		return e.lcd.GasPrice
+	}
+	return e.gasPrice
+}
+
+// BaseFee Query the current EIP-1559 base fee of x/feemarket
+func (lcd *LCDClient) BaseFee(ctx context.Context) (msg.Int, error) {
+	var response feemarkettypes.QueryBaseFeeResponse
+	if err := lcd.queryJSON(ctx, "/ethermint/feemarket/v1/base_fee", &response); err != nil {
+		return msg.Int{}, err
+	}
+	if response.BaseFee == nil {
+		return sdk.ZeroInt(), nil
+	}
+	return *response.BaseFee, nil
+}
+
+// FeeMarketParams Query the params of x/feemarket
+func (lcd *LCDClient) FeeMarketParams(ctx context.Context) (*feemarkettypes.Params, error) {
+	var response feemarkettypes.QueryParamsResponse
+	if err := lcd.queryJSON(ctx, "/ethermint/feemarket/v1/params", &response); err != nil {
+		return nil, err
+	}
+	return &response.Params, nil
+}
+
+// EstimateGasPrice returns the gas price the client pays for txs without FeeAmount,
+// the x/feemarket base fee plus tip unless the client is created WithFixedGasPrice
+func (lcd *LCDClient) EstimateGasPrice(ctx context.Context) (msg.DecCoin, error) {
+	return lcd.currentGasPrice(ctx)
+}
+
+// currentGasPrice returns the estimated gas price if the client has a fee estimator, otherwise the client gas price
+func (lcd *LCDClient) currentGasPrice(ctx context.Context) (msg.DecCoin, error) {
+	if lcd.feeEstimator == nil {
+		return lcd.GasPrice, nil
+	}
+	return lcd.feeEstimator.GasPrice(ctx)
+}
+
+// offlineGasPrice returns the gas price of txs generated without querying the chain,
+// the last price estimated by the client if higher than the client gas price
+func (lcd *LCDClient) offlineGasPrice() msg.DecCoin {
+	if lcd.feeEstimator == nil {
+		return lcd.GasPrice
+	}
+	return lcd.feeEstimator.lastGasPrice()
+}
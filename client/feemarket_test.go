@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FeeEstimator(t *testing.T) {
+	baseFeeCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ethermint/feemarket/v1/base_fee":
+			baseFeeCalls++
+			w.Write([]byte(`{"base_fee":"1000"}`))
+		case "/ethermint/feemarket/v1/params":
+			w.Write([]byte(`{"params":{"no_base_fee":false,"base_fee_change_denominator":8,"elasticity_multiplier":2,"enable_height":"0","base_fee":"1000","min_gas_price":"500.000000000000000000","min_gas_multiplier":"0.500000000000000000"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithFeeMarket(PercentTip(mustParseDecFromStr("0.1")), time.Minute))
+	gasPrice, err := lcd.currentGasPrice(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "1100.000000000000000000agli", gasPrice.String())
+
+	_, err = lcd.currentGasPrice(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, baseFeeCalls)
+
+	assert.Equal(t, "2200000agli", calculateFee(gasPrice, 2000).String())
+}
+
+func Test_FeeEstimatorConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ethermint/feemarket/v1/base_fee":
+			<-release
+			w.Write([]byte(`{"base_fee":"1000"}`))
+		case "/ethermint/feemarket/v1/params":
+			w.Write([]byte(`{"params":{"no_base_fee":false,"base_fee_change_denominator":8,"elasticity_multiplier":2,"enable_height":"0","base_fee":"1000","min_gas_price":"0","min_gas_multiplier":"0.5"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+
+	// callers waiting for a hung base fee query do not block the callers giving up on their ctx
+	var wg sync.WaitGroup
+	prices := make([]msg.DecCoin, 4)
+	errs := make([]error, len(prices))
+	for i := range prices {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			prices[i], errs[i] = lcd.EstimateGasPrice(context.Background())
+		}(i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := lcd.EstimateGasPrice(ctx)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	close(release)
+	wg.Wait()
+	for i := range prices {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "1000.000000000000000000agli", prices[i].String())
+	}
+	// the price estimated by the waiting callers is cached
+	gasPrice, err := lcd.EstimateGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1000.000000000000000000agli", gasPrice.String())
+}
+
+func Test_DefaultGasPrice(t *testing.T) {
+	feeMarket := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !feeMarket:
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/ethermint/feemarket/v1/base_fee":
+			w.Write([]byte(`{"base_fee":"1000"}`))
+		case r.URL.Path == "/ethermint/feemarket/v1/params":
+			w.Write([]byte(`{"params":{"no_base_fee":false,"base_fee_change_denominator":8,"elasticity_multiplier":2,"enable_height":"0","base_fee":"1000","min_gas_price":"0","min_gas_multiplier":"0.5"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	from := msg.AccAddress(make([]byte, 20))
+	options := CreateTxOptions{Msgs: []msg.Msg{msg.NewMsgSend(from, from, msg.NewCoins(msg.NewInt64Coin("agli", 1)))}, GasLimit: 10}
+	generatedFee := func(lcd *LCDClient) string {
+		unsigned, err := lcd.GenerateTx(options)
+		require.NoError(t, err)
+		txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), unsigned)
+		require.NoError(t, err)
+		return txbuilder.GetTx().GetFee().String()
+	}
+
+	// the base fee is paid by default, offline txs pay the last estimated price
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	assert.Equal(t, "10agli", generatedFee(lcd))
+	gasPrice, err := lcd.EstimateGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1000.000000000000000000agli", gasPrice.String())
+	assert.Equal(t, "10000agli", generatedFee(lcd))
+
+	// the client gas price is the floor
+	floor := msg.NewDecCoinFromDec("agli", mustParseDecFromStr("2000"))
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithGasFeeConfig(floor, mustParseDecFromStr("1.5")))
+	gasPrice, err = lcd.EstimateGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, floor, gasPrice)
+
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithFixedGasPrice())
+	gasPrice, err = lcd.EstimateGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, lcd.GasPrice, gasPrice)
+
+	// nodes without x/feemarket are paid the client gas price
+	feeMarket = false
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	gasPrice, err = lcd.EstimateGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, lcd.GasPrice, gasPrice)
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// maxGasSamples number of observations kept per statement shape by LinearGasStrategy
+const maxGasSamples = 32
+
+// GasSimulator simulates the tx being estimated, it returns the simulated gas used
+// and the gas limit adjusted by the client gas adjustment. Strategies with a margin
+// apply their margin to the gas used instead, so simulated and cached limits agree.
+type GasSimulator func(ctx context.Context) (gasLimit, gasUsed uint64, err error)
+
+// GasStrategy decides the gas limit of txs created without GasLimit
+type GasStrategy interface {
+	// EstimateGas returns the gas limit of a tx of msgs
+	EstimateGas(ctx context.Context, msgs []msg.Msg, simulate GasSimulator) (uint64, error)
+	// ObserveGasUsed feeds back the gas used by a committed tx of msgs the client broadcast
+	ObserveGasUsed(msgs []msg.Msg, gasUsed uint64)
+}
+
+// SimulateGasStrategy simulate every tx, this is the default strategy
+type SimulateGasStrategy struct{}
+
+// EstimateGas simulate the tx
+func (SimulateGasStrategy) EstimateGas(ctx context.Context, _ []msg.Msg, simulate GasSimulator) (uint64, error) {
+	gasLimit, _, err := simulate(ctx)
+	return gasLimit, err
+}
+
+// ObserveGasUsed nothing to learn
+func (SimulateGasStrategy) ObserveGasUsed([]msg.Msg, uint64) {}
+
+// CachedGasStrategy simulate a tx shape once, then reuse its gas used plus a safety margin.
+// Txs share a shape when their msgs have the same types, sql fingerprints and argument size class.
+type CachedGasStrategy struct {
+	margin msg.Dec
+
+	mu    sync.Mutex
+	cache map[string]uint64
+}
+
+// NewCachedGasStrategy create cached strategy, margin 0.2 adds 20% to the cached gas
+func NewCachedGasStrategy(margin msg.Dec) *CachedGasStrategy {
+	return &CachedGasStrategy{
+		margin: margin,
+		cache:  map[string]uint64{},
+	}
+}
+
+// EstimateGas returns the cached gas of the tx shape, or simulate on a miss
+func (s *CachedGasStrategy) EstimateGas(ctx context.Context, msgs []msg.Msg, simulate GasSimulator) (uint64, error) {
+	key := gasFingerprint(msgs)
+	s.mu.Lock()
+	gas, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok {
+		return withGasMargin(gas, s.margin), nil
+	}
+
+	_, gasUsed, err := simulate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.ObserveGasUsed(msgs, gasUsed)
+	return withGasMargin(gasUsed, s.margin), nil
+}
+
+// ObserveGasUsed cache the gas used, the highest gas seen for a shape is kept
+func (s *CachedGasStrategy) ObserveGasUsed(msgs []msg.Msg, gasUsed uint64) {
+	key := gasFingerprint(msgs)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if gasUsed > s.cache[key] {
+		s.cache[key] = gasUsed
+	}
+}
+
+// FixedGasStrategy look up the gas of sql statements in a table keyed by statement kind,
+// such as INSERT or CREATE. Txs with other msgs or kinds missing from the table use fallback.
+type FixedGasStrategy struct {
+	table    map[string]uint64
+	fallback GasStrategy
+}
+
+// NewFixedGasStrategy create fixed strategy, fallback defaults to SimulateGasStrategy
+func NewFixedGasStrategy(table map[string]uint64, fallback GasStrategy) *FixedGasStrategy {
+	if fallback == nil {
+		fallback = SimulateGasStrategy{}
+	}
+	fixed := make(map[string]uint64, len(table))
+	for kind, gas := range table {
+		fixed[strings.ToUpper(kind)] = gas
+	}
+	return &FixedGasStrategy{
+		table:    fixed,
+		fallback: fallback,
+	}
+}
+
+// EstimateGas returns the sum of the table gas of every statement
+func (s *FixedGasStrategy) EstimateGas(ctx context.Context, msgs []msg.Msg, simulate GasSimulator) (uint64, error) {
+	var total uint64
+	for _, m := range msgs {
+		exec, ok := m.(*glittertypes.SQLExecRequest)
+		if !ok {
+			return s.fallback.EstimateGas(ctx, msgs, simulate)
+		}
+		gas, ok := s.table[utils.StatementKind(exec.Sql)]
+		if !ok {
+			return s.fallback.EstimateGas(ctx, msgs, simulate)
+		}
+		total += gas
+	}
+	return total, nil
+}
+
+// ObserveGasUsed feeds back to the fallback strategy
+func (s *FixedGasStrategy) ObserveGasUsed(msgs []msg.Msg, gasUsed uint64) {
+	s.fallback.ObserveGasUsed(msgs, gasUsed)
+}
+
+// LinearGasStrategy model the gas of a single-statement INSERT as a linear function of its row count.
+// The first insert into a table shape is simulated, later inserts are predicted from the observed
+// gas plus a safety margin, the model is refined as committed gas is fed back.
+// Other txs use fallback.
+type LinearGasStrategy struct {
+	margin   msg.Dec
+	fallback GasStrategy
+
+	mu      sync.Mutex
+	samples map[string][]gasSample
+}
+
+type gasSample struct {
+	rows int
+	gas  uint64
+}
+
+// NewLinearGasStrategy create linear strategy, fallback defaults to SimulateGasStrategy
+func NewLinearGasStrategy(margin msg.Dec, fallback GasStrategy) *LinearGasStrategy {
+	if fallback == nil {
+		fallback = SimulateGasStrategy{}
+	}
+	return &LinearGasStrategy{
+		margin:   margin,
+		fallback: fallback,
+		samples:  map[string][]gasSample{},
+	}
+}
+
+// EstimateGas predict the gas of an insert from its row count
+func (s *LinearGasStrategy) EstimateGas(ctx context.Context, msgs []msg.Msg, simulate GasSimulator) (uint64, error) {
+	key, rows, ok := insertShape(msgs)
+	if !ok {
+		return s.fallback.EstimateGas(ctx, msgs, simulate)
+	}
+
+	s.mu.Lock()
+	gas, ok := predictGas(s.samples[key], rows)
+	s.mu.Unlock()
+	if ok {
+		return withGasMargin(gas, s.margin), nil
+	}
+
+	_, gasUsed, err := simulate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.ObserveGasUsed(msgs, gasUsed)
+	return withGasMargin(gasUsed, s.margin), nil
+}
+
+// ObserveGasUsed add a sample to the model of the insert shape
+func (s *LinearGasStrategy) ObserveGasUsed(msgs []msg.Msg, gasUsed uint64) {
+	key, rows, ok := insertShape(msgs)
+	if !ok {
+		s.fallback.ObserveGasUsed(msgs, gasUsed)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	samples := append(s.samples[key], gasSample{rows: rows, gas: gasUsed})
+	if len(samples) > maxGasSamples {
+		samples = samples[len(samples)-maxGasSamples:]
+	}
+	s.samples[key] = samples
+}
+
+// predictGas fit gas = base + perRow*rows by least squares. With a single row count observed,
+// the gas is scaled by rows, which over-estimates as the base cost is scaled too.
+func predictGas(samples []gasSample, rows int) (uint64, bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	var n, sumX, sumY, sumXX, sumXY float64
+	var maxGas uint64
+	maxRows := 0
+	distinct := false
+	for _, sample := range samples {
+		x, y := float64(sample.rows), float64(sample.gas)
+		n++
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+		if sample.rows != samples[0].rows {
+			distinct = true
+		}
+		if sample.rows > maxRows || (sample.rows == maxRows && sample.gas > maxGas) {
+			maxRows, maxGas = sample.rows, sample.gas
+		}
+	}
+
+	if distinct {
+		perRow := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+		base := (sumY - perRow*sumX) / n
+		if perRow > 0 && base >= 0 {
+			return uint64(math.Ceil(base + perRow*float64(rows))), true
+		}
+	}
+	if rows <= maxRows {
+		return maxGas, true
+	}
+	return uint64(math.Ceil(float64(maxGas) * float64(rows) / float64(maxRows))), true
+}
+
+// insertShape returns the fingerprint of the statement before VALUES and the row count
+// if msgs is a single INSERT ... VALUES statement
+func insertShape(msgs []msg.Msg) (string, int, bool) {
+	if len(msgs) != 1 {
+		return "", 0, false
+	}
+	exec, ok := msgs[0].(*glittertypes.SQLExecRequest)
+	if !ok {
+		return "", 0, false
+	}
+	head, rows, ok := utils.SplitInsertValues(exec.Sql)
+	if !ok {
+		return "", 0, false
+	}
+	return utils.SQLFingerprint(head), rows, true
+}
+
+// gasFingerprint returns the shape of msgs, sql statements are keyed by their fingerprint
+// and the power of two class of their argument size
+func gasFingerprint(msgs []msg.Msg) string {
+	b := strings.Builder{}
+	for i, m := range msgs {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		b.WriteString(sdk.MsgTypeURL(m))
+		exec, ok := m.(*glittertypes.SQLExecRequest)
+		if !ok {
+			continue
+		}
+		size := 0
+		for _, arg := range exec.Arguments {
+			size += len(arg.Value)
+		}
+		b.WriteString(fmt.Sprintf(":%s:%d", utils.SQLFingerprint(exec.Sql), bits.Len(uint(size))))
+	}
+	return b.String()
+}
+
+// withGasMargin returns gas increased by margin, rounded up
+func withGasMargin(gas uint64, margin msg.Dec) uint64 {
+	if margin.IsNil() {
+		return gas
+	}
+	return uint64(sdk.NewDec(int64(gas)).Mul(sdk.OneDec().Add(margin)).Ceil().TruncateInt64())
+}
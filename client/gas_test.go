@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type simulateCounter struct {
+	calls   int
+	gasUsed uint64
+}
+
+func (s *simulateCounter) simulate(context.Context) (uint64, uint64, error) {
+	s.calls++
+	return s.gasUsed * 2, s.gasUsed, nil
+}
+
+func sqlMsgs(sql string) []msg.Msg {
+	return []msg.Msg{&glittertypes.SQLExecRequest{Sql: sql}}
+}
+
+func Test_CachedGasStrategy(t *testing.T) {
+	ctx := context.Background()
+	sim := &simulateCounter{gasUsed: 1000}
+	s := NewCachedGasStrategy(mustParseDecFromStr("0.2"))
+
+	// a miss adds the margin to the simulated gas as a hit does
+	gas, err := s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (1)"), sim.simulate)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1200), gas)
+
+	gas, err = s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (2)"), sim.simulate)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1200), gas)
+	assert.Equal(t, 1, sim.calls)
+
+	s.ObserveGasUsed(sqlMsgs("insert into db.t (a) values (3)"), 1500)
+	gas, _ = s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (4)"), sim.simulate)
+	assert.Equal(t, uint64(1800), gas)
+	assert.Equal(t, 1, sim.calls)
+}
+
+func Test_FixedGasStrategy(t *testing.T) {
+	ctx := context.Background()
+	sim := &simulateCounter{gasUsed: 1000}
+	s := NewFixedGasStrategy(map[string]uint64{"insert": 300, "CREATE": 5000}, nil)
+
+	gas, err := s.EstimateGas(ctx, append(sqlMsgs("INSERT INTO db.t VALUES (1)"), sqlMsgs(" create table db.t2 (a int)")...), sim.simulate)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5300), gas)
+	assert.Equal(t, 0, sim.calls)
+
+	gas, _ = s.EstimateGas(ctx, sqlMsgs("delete from db.t"), sim.simulate)
+	assert.Equal(t, uint64(2000), gas)
+	assert.Equal(t, 1, sim.calls)
+}
+
+func Test_LinearGasStrategy(t *testing.T) {
+	ctx := context.Background()
+	sim := &simulateCounter{gasUsed: 1100}
+	s := NewLinearGasStrategy(mustParseDecFromStr("0"), nil)
+
+	gas, err := s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (?),(?)"), sim.simulate)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1100), gas)
+
+	// scaled from the single observation
+	gas, _ = s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (?),(?),(?),(?)"), sim.simulate)
+	assert.Equal(t, uint64(2200), gas)
+	gas, _ = s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (?)"), sim.simulate)
+	assert.Equal(t, uint64(1100), gas)
+	assert.Equal(t, 1, sim.calls)
+
+	// fitted to gas = 1000 + 50*rows
+	s.ObserveGasUsed(sqlMsgs("insert into db.t (a) values (?),(?),(?),(?),(?),(?)"), 1300)
+	gas, _ = s.EstimateGas(ctx, sqlMsgs("insert into db.t (a) values (?),(?),(?),(?),(?),(?),(?),(?),(?),(?)"), sim.simulate)
+	assert.Equal(t, uint64(1500), gas)
+	assert.Equal(t, 1, sim.calls)
+}
+
+// recordingGasStrategy records the observed gas
+type recordingGasStrategy struct {
+	SimulateGasStrategy
+	observed []uint64
+}
+
+func (s *recordingGasStrategy) ObserveGasUsed(_ []msg.Msg, gasUsed uint64) {
+	s.observed = append(s.observed, gasUsed)
+}
+
+func Test_ObserveGasUsed(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	m, err := codectypes.NewAnyWithValue(&glittertypes.SQLExecRequest{Sql: "insert into db.t (a) values (1)"})
+	require.NoError(t, err)
+	body, err := lcd.GetMarshaler().MarshalJSON(&txtypes.GetTxResponse{
+		Tx:         &txtypes.Tx{Body: &txtypes.TxBody{Messages: []*codectypes.Any{m}}, AuthInfo: &txtypes.AuthInfo{}},
+		TxResponse: &sdk.TxResponse{TxHash: "A1", GasUsed: 1500},
+	})
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	strategy := &recordingGasStrategy{}
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithGasStrategy(strategy))
+	// txs of other clients are not observed
+	committed, err := lcd.GetTx(context.Background(), "A1")
+	require.NoError(t, err)
+	assert.Empty(t, strategy.observed)
+
+	lcd.observeGasUsed(committed)
+	assert.Equal(t, []uint64{1500}, strategy.observed)
+}
@@ -2,13 +2,17 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/glitternetwork/glitter-sdk-go/address"
 	"github.com/glitternetwork/glitter-sdk-go/key"
 	"github.com/glitternetwork/glitter-sdk-go/msg"
 	"github.com/glitternetwork/glitter-sdk-go/tx"
@@ -18,14 +22,28 @@ import (
 // LCDClient outer interface for building & signing & broadcasting tx
 type LCDClient struct {
 	URL           string
+	RPCURL        string
 	ChainID       string
 	GasPrice      msg.DecCoin
 	GasAdjustment msg.Dec
+	FeeGranter    msg.AccAddress
+	// Bech32 codec of the account addresses of the chain
+	Bech32 msg.Bech32Codec
 
 	PrivKey        key.PrivKey
 	EncodingConfig EncodingConfig
 
-	c *http.Client
+	c            *http.Client
+	feeEstimator *FeeEstimator
+	gasStrategy  GasStrategy
+	dryRun       bool
+
+	handshake     *NodeRequirements
+	handshakeMu   sync.Mutex
+	handshakeDone bool
+
+	mu             sync.Mutex
+	denomsMetadata []banktypes.Metadata
 }
 
 func (lcd *LCDClient) GetMarshaler() codec.Codec {
@@ -42,15 +60,25 @@ func New(chainID string, privateKey key.PrivKey, options ...Option) *LCDClient {
 	for _, o := range options {
 		o.apply(&opt)
 	}
-	return &LCDClient{
+	lcd := &LCDClient{
 		URL:            opt.endpoint,
+		RPCURL:         opt.rpcEndpoint,
 		ChainID:        chainID,
 		GasPrice:       opt.gasPrice,
 		GasAdjustment:  opt.gasAdjustment,
+		FeeGranter:     opt.feeGranter,
+		Bech32:         opt.bech32,
 		PrivKey:        privateKey,
 		EncodingConfig: MakeEncodingConfig(ModuleBasics),
 		c:              &http.Client{Timeout: opt.httpTimeout},
+		gasStrategy:    opt.gasStrategy,
+		dryRun:         opt.dryRun,
+		handshake:      opt.handshake,
 	}
+	if opt.feeMarket {
+		lcd.feeEstimator = NewFeeEstimator(lcd, opt.feeMarketTip, opt.feeMarketTTL)
+	}
+	return lcd
 }
 
 // CreateTxOptions tx creation options
@@ -67,19 +95,39 @@ type CreateTxOptions struct {
 	SignMode      tx.SignMode
 	FeeGranter    msg.AccAddress
 	TimeoutHeight uint64
+
+	// maxGas the gas budget set by Batch.Commit, the tx is not signed if its gas limit exceeds it
+	maxGas uint64
 }
 
-// CreateAndSignTx build and sign tx
-func (lcd *LCDClient) CreateAndSignTx(ctx context.Context, options CreateTxOptions) (*tx.Builder, error) {
+// checkMaxGas returns ErrBatchBudgetExceeded if gasLimit exceeds the gas budget of options
+func (options CreateTxOptions) checkMaxGas(gasLimit uint64) error {
+	if options.maxGas > 0 && gasLimit > options.maxGas {
+		return fmt.Errorf("%w: gas %d exceeds max gas %d", ErrBatchBudgetExceeded, gasLimit, options.maxGas)
+	}
+	return nil
+}
+
+// prepareTx build the unsigned tx of options and fill the defaults of options
+func (lcd *LCDClient) prepareTx(ctx context.Context, options *CreateTxOptions) (tx.Builder, error) {
 	txbuilder := tx.NewTxBuilder(lcd.GetTxConfig())
+	if err := lcd.handshakeOnce(ctx); err != nil {
+		return txbuilder, err
+	}
+	if options.FeeGranter.Empty() {
+		options.FeeGranter = lcd.FeeGranter
+	}
+
 	txbuilder.SetFeeAmount(options.FeeAmount)
-	txbuilder.SetFeeGranter(options.FeeGranter)
+	if err := txbuilder.SetFeeGranterBech32(lcd.bech32(options.FeeGranter)); err != nil {
+		return txbuilder, err
+	}
 	txbuilder.SetGasLimit(options.GasLimit)
 	txbuilder.SetMemo(options.Memo)
 	txbuilder.SetTimeoutHeight(options.TimeoutHeight)
 	err := txbuilder.SetMsgs(options.Msgs...)
 	if err != nil {
-		return &txbuilder, err
+		return txbuilder, err
 	}
 
 	// use direct sign mode as default
@@ -88,39 +136,53 @@ func (lcd *LCDClient) CreateAndSignTx(ctx context.Context, options CreateTxOptio
 	}
 
 	if options.AccountNumber == 0 || options.Sequence == 0 {
-		account, err := lcd.LoadAccount(ctx, msg.AccAddress(lcd.PrivKey.PubKey().Address()))
+		account, err := lcd.LoadAccount(ctx, lcd.GetAddress())
 		if err != nil {
-			return nil, sdkerrors.Wrap(err, "failed to load account")
+			return txbuilder, sdkerrors.Wrap(err, "failed to load account")
 		}
 
 		options.AccountNumber = account.GetAccountNumber()
 		options.Sequence = account.GetSequence()
 		time.Sleep(time.Second)
 	}
+	return txbuilder, nil
+}
+
+// CreateAndSignTx build and sign tx
+func (lcd *LCDClient) CreateAndSignTx(ctx context.Context, options CreateTxOptions) (*tx.Builder, error) {
+	txbuilder, err := lcd.prepareTx(ctx, &options)
+	if err != nil {
+		return nil, err
+	}
 
 	gasLimit := int64(options.GasLimit)
 	if options.GasLimit == 0 {
-		simulateRes, err := lcd.Simulate(ctx, txbuilder, options)
+		estimated, err := lcd.estimateGas(ctx, txbuilder, options)
 		if err != nil {
-			return nil, sdkerrors.Wrap(err, "failed to simulate")
+			return nil, err
 		}
 
-		gasLimit = lcd.GasAdjustment.MulInt64(int64(simulateRes.GasInfo.GasUsed)).TruncateInt64()
-		txbuilder.SetGasLimit(uint64(gasLimit))
+		gasLimit = int64(estimated)
+		txbuilder.SetGasLimit(estimated)
+	}
+	if err := options.checkMaxGas(uint64(gasLimit)); err != nil {
+		return nil, err
 	}
 
 	if options.FeeAmount.IsZero() {
-		gasFee := msg.NewCoin(lcd.GasPrice.Denom, lcd.GasPrice.Amount.MulInt64(gasLimit).TruncateInt())
-		coins := msg.Coins{}.Add(gasFee)
-		txbuilder.SetFeeAmount(coins)
+		gasPrice, err := lcd.currentGasPrice(ctx)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to estimate gas price")
+		}
+		txbuilder.SetFeeAmount(calculateFee(gasPrice, gasLimit))
 	} else {
 		txbuilder.SetFeeAmount(options.FeeAmount)
 	}
-	err = txbuilder.Sign(options.SignMode, tx.SignerData{
-		AccountNumber: options.AccountNumber,
-		ChainID:       lcd.ChainID,
-		Sequence:      options.Sequence,
-	}, lcd.PrivKey, true)
+	signerData, err := lcd.signerData(options.AccountNumber, options.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	err = txbuilder.Sign(options.SignMode, signerData, lcd.PrivKey, true)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "failed to sign tx")
 	}
@@ -128,8 +190,36 @@ func (lcd *LCDClient) CreateAndSignTx(ctx context.Context, options CreateTxOptio
 	return &txbuilder, nil
 }
 
-// SignAndBroadcastTX sign and broadcast transaction
+// estimateGas returns the gas limit of the unsigned tx decided by the client gas strategy
+func (lcd *LCDClient) estimateGas(ctx context.Context, txbuilder tx.Builder, options CreateTxOptions) (uint64, error) {
+	simulate := func(ctx context.Context) (uint64, uint64, error) {
+		simulateRes, err := lcd.Simulate(ctx, txbuilder, options)
+		if err != nil {
+			return 0, 0, sdkerrors.Wrap(err, "failed to simulate")
+		}
+		gasUsed := simulateRes.GasInfo.GasUsed
+		return uint64(lcd.GasAdjustment.MulInt64(int64(gasUsed)).TruncateInt64()), gasUsed, nil
+	}
+	return lcd.gasStrategy.EstimateGas(ctx, options.Msgs, simulate)
+}
+
+// calculateFee returns the fee of gasLimit at gasPrice
+func calculateFee(gasPrice msg.DecCoin, gasLimit int64) msg.Coins {
+	gasFee := msg.NewCoin(gasPrice.Denom, gasPrice.Amount.MulInt64(gasLimit).TruncateInt())
+	return msg.Coins{}.Add(gasFee)
+}
+
+// SignAndBroadcastTX sign and broadcast transaction, the tx is only simulated if the client is in dry run mode.
+// A tx rejected by the node, or by the simulation in dry run mode, returns its response with the
+// non-zero code together with the error.
 func (lcd *LCDClient) SignAndBroadcastTX(ctx context.Context, options CreateTxOptions) (*sdk.TxResponse, error) {
+	if lcd.dryRun {
+		res, err := lcd.DryRunTx(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		return res.TxResponse(), res.Err
+	}
 	builder, err := lcd.CreateAndSignTx(ctx, options)
 	if err != nil {
 		return nil, err
@@ -142,13 +232,32 @@ func (lcd *LCDClient) GetAddress() msg.AccAddress {
 	return msg.AccAddress(lcd.PrivKey.PubKey().Address())
 }
 
+// Bech32Address returns the bech32 form of the account address, such as glitter1...
+func (lcd *LCDClient) Bech32Address() string {
+	return lcd.bech32(lcd.GetAddress())
+}
+
+// bech32 returns the bech32 form of addr by the codec of the client
+func (lcd *LCDClient) bech32(addr msg.AccAddress) string {
+	return lcd.Bech32.AccAddressString(addr)
+}
+
+// normalizeUID returns the bech32 form of the bech32 or 0x... address uid
+func (lcd *LCDClient) normalizeUID(uid string) (string, error) {
+	a, err := address.ParseWithPrefix(uid, lcd.Bech32.AccountPrefix)
+	if err != nil {
+		return "", sdkerrors.Wrap(err, "invalid uid")
+	}
+	return a.Bech32(lcd.Bech32.AccountPrefix), nil
+}
+
 // SQLExecWithOptions Execute a SQL with options
 // Args:
 // sql: SQL statement to execute
 // args: Parameters of the SQL statement, default to None
 // Returns: Transaction information of the SQL execution
 func (lcd *LCDClient) SQLExecWithOptions(ctx context.Context, options CreateTxOptions, sql string, args []*glittertypes.Argument) (*sdk.TxResponse, error) {
-	_msg := glittertypes.NewSQLExecRequest(lcd.GetAddress(), sql, args)
+	_msg := lcd.newSQLExecRequest(sql, args)
 	options.Msgs = []msg.Msg{_msg}
 	return lcd.SignAndBroadcastTX(ctx, options)
 }
@@ -162,34 +271,35 @@ func (lcd *LCDClient) SQLExec(ctx context.Context, sql string, args []*glitterty
 	return lcd.SQLExecWithOptions(ctx, CreateTxOptions{SignMode: tx.SignModeDirect}, sql, args)
 }
 
-const (
-	GrantWriter = "writer"
-	GrantReader = "reader"
-	GrantOwner  = "admin"
-)
-
-func (lcd *LCDClient) sqlGrantWithOptions(ctx context.Context, options CreateTxOptions, onDatabase string, onTable string, toUID string, role string) (*sdk.TxResponse, error) {
-	_msg := glittertypes.NewSQLGrantRequest(lcd.GetAddress(), onDatabase, onTable, toUID, role)
+func (lcd *LCDClient) sqlGrantWithOptions(ctx context.Context, options CreateTxOptions, onDatabase string, onTable string, toUID string, role Role) (*sdk.TxResponse, error) {
+	if err := role.ValidateScope(onDatabase, onTable); err != nil {
+		return nil, err
+	}
+	toUID, err := lcd.normalizeUID(toUID)
+	if err != nil {
+		return nil, err
+	}
+	_msg := newSQLGrantRequest(lcd.Bech32Address(), onDatabase, onTable, toUID, role.String())
 	options.Msgs = []msg.Msg{_msg}
 	return lcd.SignAndBroadcastTX(ctx, options)
 }
 
 // SQLGrant Grant database or table access permission
 // Args:
-//   - toUID: Address to grant access to
-//   - role: SQL role name
+//   - toUID: Address to grant access to, in the glitter1... or 0x... form
+//   - role: SQL role, one of GrantReader, GrantWriter, GrantOwner, validated with Role.ValidateScope
 //   - onDatabase: SQL database name
 //   - onTable: SQL table name, optional (Grant authorization to the table if specified, otherwise grant authorization to the database)
 //
 // Returns:
 // Result of broadcasting grant transaction
 func (lcd *LCDClient) SQLGrant(ctx context.Context, onDatabase string, onTable string, toUID string, role string) (*sdk.TxResponse, error) {
-	return lcd.sqlGrantWithOptions(ctx, CreateTxOptions{}, onDatabase, onTable, toUID, role)
+	return lcd.sqlGrantWithOptions(ctx, CreateTxOptions{}, onDatabase, onTable, toUID, Role(role))
 }
 
 // GrantWriter (insert/update/delete) permissions on the specified table to the specified user
 // Args:
-//   - toUID: Address to grant access
+//   - toUID: Address to grant access, in the glitter1... or 0x... form
 //   - onDatabase: SQL database name
 //   - onTable: SQL table name, optional
 //
@@ -201,7 +311,7 @@ func (lcd *LCDClient) GrantWriter(ctx context.Context, onDatabase string, onTabl
 
 // GrantReader (select) permissions on the specified table to the specified user
 // Args:
-//   - toUID: Address to grant access
+//   - toUID: Address to grant access, in the glitter1... or 0x... form
 //   - onDatabase: SQL database name
 //   - onTable: SQL table name, optional
 //
@@ -211,14 +321,40 @@ func (lcd *LCDClient) GrantReader(ctx context.Context, onDatabase string, onTabl
 	return lcd.SQLGrant(ctx, onDatabase, onTable, toUID, GrantReader)
 }
 
-// GrantAdmin (admin) permissions on the specified table to the specified user
+// GrantAdmin (admin) permissions on the specified database to the specified user
 // Args:
-//   - toUID: Address to grant access
+//   - toUID: Address to grant access, in the glitter1... or 0x... form
 //   - onDatabase: SQL database name
-//   - onTable: SQL table name, optional
+//   - onTable: Must be empty, admin is only granted on a whole database
 //
 // Returns:
 // Result of grant transaction
 func (lcd *LCDClient) GrantAdmin(ctx context.Context, onDatabase string, onTable string, toUID string) (*sdk.TxResponse, error) {
 	return lcd.SQLGrant(ctx, onDatabase, onTable, toUID, GrantOwner)
 }
+
+func (lcd *LCDClient) sqlRevokeWithOptions(ctx context.Context, options CreateTxOptions, onDatabase string, onTable string, toUID string, role Role) (*sdk.TxResponse, error) {
+	if err := role.ValidateScope(onDatabase, onTable); err != nil {
+		return nil, err
+	}
+	toUID, err := lcd.normalizeUID(toUID)
+	if err != nil {
+		return nil, err
+	}
+	_msg := newSQLRevokeRequest(lcd.Bech32Address(), onDatabase, onTable, toUID, role.String())
+	options.Msgs = []msg.Msg{_msg}
+	return lcd.SignAndBroadcastTX(ctx, options)
+}
+
+// SQLRevoke Revoke database or table access permission granted by SQLGrant
+// Args:
+//   - toUID: Address to revoke access from, in the glitter1... or 0x... form
+//   - role: SQL role, one of GrantReader, GrantWriter, GrantOwner, validated with Role.ValidateScope
+//   - onDatabase: SQL database name
+//   - onTable: SQL table name, optional (Revoke the table role if specified, otherwise revoke the database role)
+//
+// Returns:
+// Result of broadcasting revoke transaction
+func (lcd *LCDClient) SQLRevoke(ctx context.Context, onDatabase string, onTable string, toUID string, role string) (*sdk.TxResponse, error) {
+	return lcd.sqlRevokeWithOptions(ctx, CreateTxOptions{}, onDatabase, onTable, toUID, Role(role))
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// the glittertypes.NewSQL*Request constructors encode uid by the global sdk.Config,
+// the client builds the messages from the bech32 uid of its own codec instead
+
+// newSQLExecRequest create SQLExecRequest signed by the client account
+func (lcd *LCDClient) newSQLExecRequest(sql string, args []*glittertypes.Argument) *glittertypes.SQLExecRequest {
+	return newSQLExecRequest(lcd.Bech32Address(), sql, args)
+}
+
+func newSQLExecRequest(uid, sql string, args []*glittertypes.Argument) *glittertypes.SQLExecRequest {
+	return &glittertypes.SQLExecRequest{Uid: uid, Sql: sql, Arguments: args}
+}
+
+func newSQLGrantRequest(uid, onDatabase, onTable, toUID, role string) *glittertypes.SQLGrantRequest {
+	return &glittertypes.SQLGrantRequest{Uid: uid, OnDatabase: onDatabase, OnTable: onTable, ToUID: toUID, Role: role}
+}
+
+func newSQLRevokeRequest(uid, onDatabase, onTable, toUID, role string) *glittertypes.SQLRevokeRequest {
+	return &glittertypes.SQLRevokeRequest{Uid: uid, OnDatabase: onDatabase, OnTable: onTable, ToUID: toUID, Role: role}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Bech32IndependentOfGlobalConfig(t *testing.T) {
+	// another chain of the process owns the global config
+	config := sdk.GetConfig()
+	prefix, pubPrefix := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	config.SetBech32PrefixForAccount("osmo", "osmopub")
+	defer config.SetBech32PrefixForAccount(prefix, pubPrefix)
+
+	lcd := newTestClient(t)
+	assert.Regexp(t, "^glitter1", lcd.Bech32Address())
+	assert.Equal(t, lcd.Bech32Address(), lcd.newSQLExecRequest("select 1", nil).Uid)
+
+	addr, err := lcd.Bech32.AccAddressFromBech32(lcd.Bech32Address())
+	require.NoError(t, err)
+	assert.Equal(t, lcd.GetAddress(), addr)
+	_, err = lcd.Bech32.AccAddressFromBech32(lcd.GetAddress().String())
+	assert.Error(t, err, "osmo address")
+	// the alias of the msg package decodes glitter addresses whatever the global config
+	addr, err = msg.AccAddressFromBech32(lcd.Bech32Address())
+	require.NoError(t, err)
+	assert.Equal(t, lcd.GetAddress(), addr)
+	_, err = msg.AccAddressFromBech32(lcd.GetAddress().String())
+	assert.Error(t, err, "osmo address")
+
+	granter := msg.AccAddress(make([]byte, 20))
+	unsigned, err := New("glitter_12000-2", nil, WithFeeGranter(granter)).GenerateTx(CreateTxOptions{
+		Msgs:     []msg.Msg{lcd.newSQLExecRequest("select 1", nil)},
+		GasLimit: 200000,
+	})
+	require.NoError(t, err)
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), unsigned)
+	require.NoError(t, err)
+	assert.Contains(t, string(unsigned), `"granter":"`+msg.GlitterBech32Codec.AccAddressString(granter)+`"`)
+	assert.NotNil(t, txbuilder.GetTx())
+
+	// the amino tx builder cannot hold a granter encoded by the codec instead of the global config
+	aminoBuilder := tx.NewTxBuilder(legacytx.StdTxConfig{Cdc: lcd.EncodingConfig.Amino})
+	assert.NoError(t, aminoBuilder.SetFeeGranterBech32(""))
+	assert.Error(t, aminoBuilder.SetFeeGranterBech32(msg.GlitterBech32Codec.AccAddressString(granter)))
+
+	other := New("glitter_12000-2", lcd.PrivKey, WithBech32Prefix("cosmos"))
+	assert.Regexp(t, "^cosmos1", other.Bech32Address())
+}
+
+func Test_NormalizeUID(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	for _, uid := range []string{
+		"glitter1q5t6prazp4wlzegvaz5sls25phyvvmq6aqpxf7",
+		"0x0517a08fa20d5Df1650cE8a90Fc1540dc8c66c1A",
+		"0x0517a08fa20d5df1650ce8a90fc1540dc8c66c1a",
+	} {
+		normalized, err := lcd.normalizeUID(uid)
+		require.NoError(t, err, uid)
+		assert.Equal(t, "glitter1q5t6prazp4wlzegvaz5sls25phyvvmq6aqpxf7", normalized)
+	}
+	_, err := lcd.normalizeUID("0x0517a08fa20d5Df1650cE8a90Fc1540dc8c66c1a")
+	assert.Error(t, err, "bad checksum")
+	_, err = lcd.SQLGrant(context.Background(), "db", "", "nobody", GrantReader)
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+)
+
+// SignMultisigTx sign a tx json offline as one member of a multisig account.
+// options must hold the account number and sequence of the multisig account.
+// The returned signature json is in the format of `glitterd tx sign --multisig --signature-only`
+// and is combined with the other members' signatures by MultiSignTx.
+func (lcd *LCDClient) SignMultisigTx(txJSON []byte, privKey key.PrivKey, options SignTxOptions) ([]byte, error) {
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), txJSON)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx")
+	}
+
+	signerData, err := lcd.signerData(options.AccountNumber, options.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := txbuilder.SignMultisigPart(signerData, privKey)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to sign tx")
+	}
+
+	return lcd.GetTxConfig().MarshalSignatureJSON([]tx.SignatureV2{sig})
+}
+
+// MultiSignTx merge the member signatures produced by SignMultisigTx (or `glitterd tx sign --multisig`)
+// into the tx json, the result is ready for BroadcastTxJSON.
+// options must hold the account number and sequence of the multisig account.
+func (lcd *LCDClient) MultiSignTx(txJSON []byte, multisigPubKey *tx.MultisigPubKey, options SignTxOptions, sigJSONs ...[]byte) ([]byte, error) {
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), txJSON)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx")
+	}
+
+	var sigs []tx.SignatureV2
+	for _, sigJSON := range sigJSONs {
+		s, err := lcd.GetTxConfig().UnmarshalSignatureJSON(sigJSON)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to decode signature")
+		}
+		sigs = append(sigs, s...)
+	}
+
+	signerData, err := lcd.signerData(options.AccountNumber, options.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	err = txbuilder.SetMultisigSignatures(multisigPubKey, signerData, sigs...)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to merge signatures")
+	}
+
+	return txbuilder.GetTxJSON()
+}
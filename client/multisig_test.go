@@ -0,0 +1,64 @@
+package client
+
+import (
+	"testing"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MultiSignTx(t *testing.T) {
+	mnemonic := mustCreateMnemonic(t)
+	var members []key.PrivKey
+	var pubKeys []cryptotypes.PubKey
+	for i := uint32(0); i < 3; i++ {
+		privKey, err := key.PrivKeyGenByMnemonic(mnemonic, key.CreateHDPath(0, i))
+		assert.NoError(t, err)
+		members = append(members, privKey)
+		pubKeys = append(pubKeys, privKey.PubKey())
+	}
+
+	multisigPubKey, err := tx.NewMultisigPubKey(2, pubKeys)
+	assert.NoError(t, err)
+	reversed, err := tx.NewMultisigPubKey(2, []cryptotypes.PubKey{pubKeys[2], pubKeys[1], pubKeys[0]})
+	assert.NoError(t, err)
+	assert.Equal(t, multisigPubKey.Address(), reversed.Address())
+	_, err = tx.NewMultisigPubKey(4, pubKeys)
+	assert.Error(t, err)
+
+	lcd := New("glitter_12000-2", nil)
+	from := msg.AccAddress(multisigPubKey.Address())
+	unsigned, err := lcd.GenerateTx(CreateTxOptions{
+		Msgs:     []msg.Msg{msg.NewMsgSend(from, from, msg.NewCoins(msg.NewInt64Coin("agli", 1)))},
+		GasLimit: 200000,
+	})
+	assert.NoError(t, err)
+
+	options := SignTxOptions{AccountNumber: 11, Sequence: 2}
+	sig0, err := lcd.SignMultisigTx(unsigned, members[0], options)
+	assert.NoError(t, err)
+	sig2, err := lcd.SignMultisigTx(unsigned, members[2], options)
+	assert.NoError(t, err)
+
+	_, err = lcd.MultiSignTx(unsigned, multisigPubKey, options, sig0)
+	assert.Error(t, err)
+	_, err = lcd.MultiSignTx(unsigned, multisigPubKey, SignTxOptions{AccountNumber: 11, Sequence: 3}, sig0, sig2)
+	assert.Error(t, err)
+	noChainID := New("", nil)
+	_, err = noChainID.SignMultisigTx(unsigned, members[0], options)
+	assert.ErrorIs(t, err, ErrEmptyChainID)
+	_, err = noChainID.MultiSignTx(unsigned, multisigPubKey, options, sig0, sig2)
+	assert.ErrorIs(t, err, ErrEmptyChainID)
+
+	signed, err := lcd.MultiSignTx(unsigned, multisigPubKey, options, sig0, sig2)
+	assert.NoError(t, err)
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), signed)
+	assert.NoError(t, err)
+	sigs, err := txbuilder.GetTx().GetSignaturesV2()
+	assert.NoError(t, err)
+	assert.Len(t, sigs, 1)
+	assert.Equal(t, multisigPubKey.Address(), sigs[0].PubKey.Address())
+}
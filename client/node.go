@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+)
+
+// ErrIncompatibleNode the node is on another chain or too old
+var ErrIncompatibleNode = errors.New("incompatible node")
+
+// NodeRequirements what the handshake requires of the node.
+// The SQL features of the glitter module are not checked: node_info reports only the chain id and
+// the application version, and the glitter module exposes no query listing its SQL features.
+// Use MinAppVersion to require the release introducing a feature.
+type NodeRequirements struct {
+	// MinAppVersion minimum application version of the node, such as v1.2.0, empty skips the check
+	MinAppVersion string
+}
+
+// NodeInfo Query the tendermint node info and the application version of the node
+func (lcd *LCDClient) NodeInfo(ctx context.Context) (*tmservice.GetNodeInfoResponse, error) {
+	var response tmservice.GetNodeInfoResponse
+	if err := lcd.queryJSON(ctx, "/cosmos/base/tendermint/v1beta1/node_info", &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Handshake check the node is compatible with the client, the chain id of the client is filled
+// from the node if empty. Only the chain id and the application version are checked, see NodeRequirements
+// Args:
+//   - req: the requirements of the node
+//
+// Returns:
+//
+//	the node info, the error wraps ErrIncompatibleNode if the node does not meet req
+func (lcd *LCDClient) Handshake(ctx context.Context, req NodeRequirements) (*tmservice.GetNodeInfoResponse, error) {
+	info, err := lcd.NodeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if info.DefaultNodeInfo == nil {
+		return nil, fmt.Errorf("%w: node %s reports no node info", ErrIncompatibleNode, lcd.URL)
+	}
+
+	network := info.DefaultNodeInfo.Network
+	if len(lcd.ChainID) == 0 {
+		lcd.ChainID = network
+	} else if lcd.ChainID != network {
+		return nil, fmt.Errorf("%w: node %s is on chain %s, client expects %s", ErrIncompatibleNode, lcd.URL, network, lcd.ChainID)
+	}
+
+	version := info.ApplicationVersion
+	if version == nil {
+		version = &tmservice.VersionInfo{}
+	}
+	if len(req.MinAppVersion) > 0 {
+		if compareVersions(version.Version, req.MinAppVersion) < 0 {
+			return nil, fmt.Errorf("%w: node %s runs %s %s, client requires %s or later",
+				ErrIncompatibleNode, lcd.URL, version.AppName, version.Version, req.MinAppVersion)
+		}
+	}
+	return info, nil
+}
+
+// handshakeOnce run the handshake of the WithHandshake option before the first signed tx,
+// a failed handshake is retried by the next tx
+func (lcd *LCDClient) handshakeOnce(ctx context.Context) error {
+	if lcd.handshake == nil {
+		return nil
+	}
+	lcd.handshakeMu.Lock()
+	defer lcd.handshakeMu.Unlock()
+	if lcd.handshakeDone {
+		return nil
+	}
+	if _, err := lcd.Handshake(ctx, *lcd.handshake); err != nil {
+		return err
+	}
+	lcd.handshakeDone = true
+	return nil
+}
+
+// compareVersions compare the dot separated numeric versions a and b, such as v1.2.3,
+// a pre-release a-rc1 is lower than a
+func compareVersions(a, b string) int {
+	split := func(v string) ([]string, bool) {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+		if i := strings.IndexByte(v, '+'); i >= 0 {
+			v = v[:i]
+		}
+		prerelease := false
+		if i := strings.IndexByte(v, '-'); i >= 0 {
+			v, prerelease = v[:i], true
+		}
+		return strings.Split(v, "."), prerelease
+	}
+	as, aPre := split(a)
+	bs, bPre := split(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case aPre && !bPre:
+		return -1
+	case !aPre && bPre:
+		return 1
+	}
+	return 0
+}
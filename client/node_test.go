@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNodeInfoServer(network, version string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/base/tendermint/v1beta1/node_info" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"default_node_info":{"network":"` + network + `","version":"0.34.21"},` +
+			`"application_version":{"name":"glitter","app_name":"glitterd","version":"` + version + `"}}`))
+	}))
+}
+
+func Test_Handshake(t *testing.T) {
+	srv := newNodeInfoServer("glitter_12000-2", "v1.3.0")
+	defer srv.Close()
+	ctx := context.Background()
+
+	lcd := New("", nil, WithChainEndpoint(srv.URL))
+	info, err := lcd.Handshake(ctx, NodeRequirements{MinAppVersion: "v1.2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "glitter_12000-2", lcd.ChainID)
+	assert.Equal(t, "glitterd", info.ApplicationVersion.AppName)
+
+	lcd = New("glitter_12001-1", nil, WithChainEndpoint(srv.URL))
+	_, err = lcd.Handshake(ctx, NodeRequirements{})
+	assert.True(t, errors.Is(err, ErrIncompatibleNode))
+	assert.Contains(t, err.Error(), "node "+srv.URL+" is on chain glitter_12000-2, client expects glitter_12001-1")
+
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	_, err = lcd.Handshake(ctx, NodeRequirements{MinAppVersion: "v1.3.1"})
+	assert.True(t, errors.Is(err, ErrIncompatibleNode))
+}
+
+func Test_HandshakeBeforeSign(t *testing.T) {
+	srv := newNodeInfoServer("glitter_12000-2", "v1.3.0")
+	defer srv.Close()
+
+	lcd := New("glitter_12001-1", nil, WithChainEndpoint(srv.URL), WithHandshake(NodeRequirements{}))
+	_, err := lcd.CreateAndSignTx(context.Background(), CreateTxOptions{})
+	assert.True(t, errors.Is(err, ErrIncompatibleNode))
+
+	p, err := (&Config{}).Profile(NetworkTestnet)
+	require.NoError(t, err)
+	p.REST = srv.URL
+	lcd, err = NewFromConfig(p)
+	require.NoError(t, err)
+	require.NoError(t, lcd.handshakeOnce(context.Background()))
+	assert.Equal(t, "glitter_12000-2", lcd.ChainID)
+}
+
+func Test_CompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("v1.2.0", "1.2"))
+	assert.Equal(t, -1, compareVersions("v1.2.0", "v1.10.0"))
+	assert.Equal(t, 1, compareVersions("v2.0.0+abc", "v1.99.9"))
+	assert.Equal(t, -1, compareVersions("v1.2.0-rc1", "v1.2.0"))
+	assert.Equal(t, -1, compareVersions("", "v0.1.0"))
+}
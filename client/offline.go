@@ -0,0 +1,95 @@
+package client
+
+import (
+	"errors"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+)
+
+// ErrEmptyChainID the client has no chain id to sign with, a signature of another chain id fails
+// to verify, so set the chain id of the client or fill it from the node by Handshake
+var ErrEmptyChainID = errors.New("empty chain id")
+
+// SignTxOptions offline tx signing options
+type SignTxOptions struct {
+	AccountNumber uint64
+	Sequence      uint64
+
+	// Optional parameters
+	SignMode tx.SignMode
+	// AppendSignature keeps the signatures already present in the tx
+	AppendSignature bool
+}
+
+// GenerateTx build an unsigned tx without querying the chain, the returned json
+// is in the format of `glitterd tx ... --generate-only` and can be signed by SignTx
+// or `glitterd tx sign --offline`.
+// GasLimit must be set. If FeeAmount is empty the fee is calculated from the gas price last estimated
+// by EstimateGasPrice, falling back to the client gas price.
+func (lcd *LCDClient) GenerateTx(options CreateTxOptions) ([]byte, error) {
+	if options.GasLimit == 0 {
+		return nil, errors.New("gas limit must be set for offline tx")
+	}
+	if options.FeeGranter.Empty() {
+		options.FeeGranter = lcd.FeeGranter
+	}
+
+	txbuilder := tx.NewTxBuilder(lcd.GetTxConfig())
+	if err := txbuilder.SetFeeGranterBech32(lcd.bech32(options.FeeGranter)); err != nil {
+		return nil, err
+	}
+	txbuilder.SetGasLimit(options.GasLimit)
+	txbuilder.SetMemo(options.Memo)
+	txbuilder.SetTimeoutHeight(options.TimeoutHeight)
+	err := txbuilder.SetMsgs(options.Msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.FeeAmount.IsZero() {
+		txbuilder.SetFeeAmount(calculateFee(lcd.offlineGasPrice(), int64(options.GasLimit)))
+	} else {
+		txbuilder.SetFeeAmount(options.FeeAmount)
+	}
+
+	return txbuilder.GetTxJSON()
+}
+
+// SignTx sign a tx json offline with privKey, the returned json is in the format
+// of `glitterd tx sign` and can be broadcast by BroadcastTxJSON or `glitterd tx broadcast`
+func (lcd *LCDClient) SignTx(txJSON []byte, privKey key.PrivKey, options SignTxOptions) ([]byte, error) {
+	txbuilder, err := tx.DecodeTxJSON(lcd.GetTxConfig(), txJSON)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx")
+	}
+
+	// use direct sign mode as default
+	if tx.SignModeUnspecified == options.SignMode {
+		options.SignMode = tx.SignModeDirect
+	}
+
+	signerData, err := lcd.signerData(options.AccountNumber, options.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	err = txbuilder.Sign(options.SignMode, signerData, privKey, !options.AppendSignature)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to sign tx")
+	}
+
+	return txbuilder.GetTxJSON()
+}
+
+// signerData returns the signer data of the account at the chain id of the client
+func (lcd *LCDClient) signerData(accountNumber, sequence uint64) (tx.SignerData, error) {
+	if len(lcd.ChainID) == 0 {
+		return tx.SignerData{}, ErrEmptyChainID
+	}
+	return tx.SignerData{
+		AccountNumber: accountNumber,
+		ChainID:       lcd.ChainID,
+		Sequence:      sequence,
+	}, nil
+}
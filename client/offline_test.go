@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OfflineSign(t *testing.T) {
+	privKey := newTestClient(t).PrivKey
+	from := msg.AccAddress(privKey.PubKey().Address())
+
+	online := New("glitter_12000-2", nil)
+	_, err := online.GenerateTx(CreateTxOptions{
+		Msgs: []msg.Msg{msg.NewMsgSend(from, from, msg.NewCoins(msg.NewInt64Coin("agli", 1)))},
+	})
+	assert.Error(t, err)
+
+	unsigned, err := online.GenerateTx(CreateTxOptions{
+		Msgs:     []msg.Msg{msg.NewMsgSend(from, from, msg.NewCoins(msg.NewInt64Coin("agli", 1)))},
+		GasLimit: 200000,
+	})
+	assert.NoError(t, err)
+
+	// a signature without the chain id would never verify
+	_, err = New("", nil).SignTx(unsigned, privKey, SignTxOptions{AccountNumber: 7, Sequence: 3})
+	assert.ErrorIs(t, err, ErrEmptyChainID)
+
+	offline := New("glitter_12000-2", nil)
+	signed, err := offline.SignTx(unsigned, privKey, SignTxOptions{AccountNumber: 7, Sequence: 3})
+	assert.NoError(t, err)
+
+	txbuilder, err := tx.DecodeTxJSON(online.GetTxConfig(), signed)
+	assert.NoError(t, err)
+	sigs, err := txbuilder.GetTx().GetSignaturesV2()
+	assert.NoError(t, err)
+	assert.Len(t, sigs, 1)
+	assert.Equal(t, uint64(3), sigs[0].Sequence)
+	assert.Equal(t, uint64(200000), txbuilder.GetTx().GetGas())
+	assert.Equal(t, "200000agli", txbuilder.GetTx().GetFee().String())
+}
@@ -26,7 +26,32 @@ func WithChainEndpoint(endpoint string) Option {
 	})
 }
 
-// WithGasFeeConfig create client with custom gas fee config
+// WithRPCEndpoint create client with the tendermint rpc endpoint, such as http://127.0.0.1:26657
+func WithRPCEndpoint(endpoint string) Option {
+	return fnOption(func(o *clientOptions) {
+		o.rpcEndpoint = endpoint
+	})
+}
+
+// WithBech32Prefix create client encoding and decoding account addresses by the bech32 prefix,
+// default to glitter
+func WithBech32Prefix(prefix string) Option {
+	return fnOption(func(o *clientOptions) {
+		o.bech32 = msg.NewBech32Codec(prefix)
+	})
+}
+
+// WithHandshake create client checking the node meets req before the first tx is signed,
+// the chain id of the client is filled from the node if empty, see LCDClient.Handshake.
+// The SQL features of the glitter module are not checked, the node does not report them
+func WithHandshake(req NodeRequirements) Option {
+	return fnOption(func(o *clientOptions) {
+		o.handshake = &req
+	})
+}
+
+// WithGasFeeConfig create client with custom gas fee config, gasPrice is the lowest price paid
+// when the client pays the x/feemarket base fee
 func WithGasFeeConfig(gasPrice msg.DecCoin, gasAdjustment msg.Dec) Option {
 	return fnOption(func(o *clientOptions) {
 		o.gasPrice = gasPrice
@@ -34,6 +59,46 @@ func WithGasFeeConfig(gasPrice msg.DecCoin, gasAdjustment msg.Dec) Option {
 	})
 }
 
+// WithFeeGranter create client whose txs are paid by the fee allowance of granter
+func WithFeeGranter(granter msg.AccAddress) Option {
+	return fnOption(func(o *clientOptions) {
+		o.feeGranter = granter
+	})
+}
+
+// WithFeeMarket create client paying the x/feemarket base fee plus tip when FeeAmount is not set,
+// the estimated gas price is cached for ttl. Clients pay the base fee without tip by default.
+func WithFeeMarket(tip TipPolicy, ttl time.Duration) Option {
+	return fnOption(func(o *clientOptions) {
+		o.feeMarket = true
+		o.feeMarketTip = tip
+		o.feeMarketTTL = ttl
+	})
+}
+
+// WithFixedGasPrice create client paying the gas price of WithGasFeeConfig instead of the x/feemarket base fee
+func WithFixedGasPrice() Option {
+	return fnOption(func(o *clientOptions) {
+		o.feeMarket = false
+	})
+}
+
+// WithGasStrategy create client estimating the gas limit of txs without GasLimit by strategy,
+// such as NewCachedGasStrategy, instead of simulating every tx
+func WithGasStrategy(strategy GasStrategy) Option {
+	return fnOption(func(o *clientOptions) {
+		o.gasStrategy = strategy
+	})
+}
+
+// WithDryRun create client simulating every tx instead of broadcasting it when dryRun is true,
+// write helpers then return the simulated result without paying fees
+func WithDryRun(dryRun bool) Option {
+	return fnOption(func(o *clientOptions) {
+		o.dryRun = dryRun
+	})
+}
+
 type fnOption func(o *clientOptions)
 
 func (f fnOption) apply(o *clientOptions) {
@@ -42,16 +107,29 @@ func (f fnOption) apply(o *clientOptions) {
 
 type clientOptions struct {
 	endpoint      string
+	rpcEndpoint   string
+	bech32        msg.Bech32Codec
 	gasPrice      msg.DecCoin
 	gasAdjustment msg.Dec
 	httpTimeout   time.Duration
+	feeGranter    msg.AccAddress
+	feeMarket     bool
+	feeMarketTip  TipPolicy
+	feeMarketTTL  time.Duration
+	gasStrategy   GasStrategy
+	dryRun        bool
+	handshake     *NodeRequirements
 }
 
 var defaultClientOptions = clientOptions{
 	endpoint:      DefaultChainEndpoint,
+	bech32:        msg.GlitterBech32Codec,
 	gasPrice:      msg.NewDecCoinFromDec("agli", mustParseDecFromStr("1")),
 	gasAdjustment: mustParseDecFromStr("2.5"),
 	httpTimeout:   time.Second * 10,
+	feeMarket:     true,
+	feeMarketTTL:  DefaultFeeEstimateTTL,
+	gasStrategy:   SimulateGasStrategy{},
 }
 
 func mustParseDecFromStr(s string) msg.Dec {
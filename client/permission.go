@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// SQLRoleGrant a role held by a user on a database or table
+type SQLRoleGrant struct {
+	Database string
+	// Table is empty for database roles
+	Table string
+	UID   string
+	Role  Role
+
+	// Granter, Height and TxHash of the tx that granted the role
+	Granter string
+	Height  int64
+	TxHash  string
+}
+
+// sqlRoleChange a grant or revoke message found on chain
+type sqlRoleChange struct {
+	grant   bool
+	granter string
+	key     [4]string // database, table, uid, role
+	height  int64
+	txHash  string
+	// txIndex orders the txs of a height, msgIndex the messages of a tx
+	txIndex  int
+	msgIndex int
+}
+
+// ListSQLGrants List the roles currently held on a database or table, replayed from the grant and revoke txs
+// Args:
+//   - database: SQL database name
+//   - table: SQL table name, optional (Only return the database roles and the roles on this table if specified)
+//
+// Returns:
+// The roles ordered by the height they were granted at
+func (lcd *LCDClient) ListSQLGrants(ctx context.Context, database, table string) ([]*SQLRoleGrant, error) {
+	var changes []sqlRoleChange
+	collected := map[string]bool{}
+	collect := func(t *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		// a tx of both grants and revokes is found by both searches
+		if txResponse.Code != 0 || collected[txResponse.TxHash] {
+			return nil
+		}
+		collected[txResponse.TxHash] = true
+		for i, m := range t.GetMsgs() {
+			var c sqlRoleChange
+			switch v := m.(type) {
+			case *glittertypes.SQLGrantRequest:
+				c = sqlRoleChange{grant: true, granter: v.Uid, key: [4]string{v.OnDatabase, v.OnTable, v.ToUID, v.Role}}
+			case *glittertypes.SQLRevokeRequest:
+				c = sqlRoleChange{grant: false, granter: v.Uid, key: [4]string{v.OnDatabase, v.OnTable, v.ToUID, v.Role}}
+			default:
+				continue
+			}
+			onDatabase, onTable := c.key[0], c.key[1]
+			if onDatabase != database || (len(table) > 0 && len(onTable) > 0 && onTable != table) {
+				continue
+			}
+			c.height = txResponse.Height
+			c.txHash = txResponse.TxHash
+			// the searches return the txs in block order, which orders the txs of one search
+			c.txIndex = len(collected)
+			c.msgIndex = i
+			changes = append(changes, c)
+		}
+		return nil
+	}
+
+	grantAction := sdk.MsgTypeURL(&glittertypes.SQLGrantRequest{})
+	if err := lcd.walkTxs(ctx, []string{fmt.Sprintf("message.action='%s'", grantAction)}, collect); err != nil {
+		return nil, err
+	}
+	revokeAction := sdk.MsgTypeURL(&glittertypes.SQLRevokeRequest{})
+	if err := lcd.walkTxs(ctx, []string{fmt.Sprintf("message.action='%s'", revokeAction)}, collect); err != nil {
+		return nil, err
+	}
+
+	if err := lcd.resolveTxIndexes(ctx, changes); err != nil {
+		return nil, err
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.height != b.height {
+			return a.height < b.height
+		}
+		if a.txIndex != b.txIndex {
+			return a.txIndex < b.txIndex
+		}
+		return a.msgIndex < b.msgIndex
+	})
+
+	current := make(map[[4]string]*SQLRoleGrant)
+	for _, c := range changes {
+		if !c.grant {
+			delete(current, c.key)
+			continue
+		}
+		if _, ok := current[c.key]; ok {
+			continue
+		}
+		current[c.key] = &SQLRoleGrant{
+			Database: c.key[0],
+			Table:    c.key[1],
+			UID:      c.key[2],
+			Role:     Role(c.key[3]),
+			Granter:  c.granter,
+			Height:   c.height,
+			TxHash:   c.txHash,
+		}
+	}
+
+	grants := make([]*SQLRoleGrant, 0, len(current))
+	for _, g := range current {
+		grants = append(grants, g)
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].Height != grants[j].Height {
+			return grants[i].Height < grants[j].Height
+		}
+		return grants[i].TxHash < grants[j].TxHash
+	})
+	return grants, nil
+}
+
+// resolveTxIndexes set the index in the block of the txs of the heights holding both grants and revokes,
+// the order of the two searches does not tell which of them came first
+func (lcd *LCDClient) resolveTxIndexes(ctx context.Context, changes []sqlRoleChange) error {
+	kinds := map[int64]map[bool]bool{}
+	for _, c := range changes {
+		if kinds[c.height] == nil {
+			kinds[c.height] = map[bool]bool{}
+		}
+		kinds[c.height][c.grant] = true
+	}
+	indexes := map[int64]map[string]int{}
+	for i, c := range changes {
+		if len(kinds[c.height]) < 2 {
+			continue
+		}
+		if _, ok := indexes[c.height]; !ok {
+			blockIndexes, err := lcd.blockTxIndexes(ctx, c.height)
+			if err != nil {
+				return err
+			}
+			indexes[c.height] = blockIndexes
+		}
+		index, ok := indexes[c.height][strings.ToUpper(c.txHash)]
+		if !ok {
+			return fmt.Errorf("tx %s not found in block %d", c.txHash, c.height)
+		}
+		changes[i].txIndex = index
+	}
+	return nil
+}
+
+// CheckPermission Check whether uid may perform action on a database or table before sending the tx
+// Args:
+//   - uid: Address of the user, in the glitter1... or 0x... form
+//   - db: SQL database name
+//   - table: SQL table name, optional
+//   - action: One of ActionSelect, ActionInsert, ActionUpdate, ActionDelete, ActionCreate, ActionAlter, ActionDrop, ActionGrant
+//
+// The creator of a database is its admin on chain, it may perform every action on all the tables of the
+// database, including the tables created by others. The creator of a table may select, insert, update,
+// delete, alter and drop it, but needs a role to grant roles or create tables. Other users need a database
+// or table role allowing action.
+//
+// Returns:
+// True if uid created the database, created the table and the creator may perform action, or holds a role allowing action
+func (lcd *LCDClient) CheckPermission(ctx context.Context, uid, db, table string, action Action) (bool, error) {
+	if err := action.Validate(); err != nil {
+		return false, err
+	}
+	uid, err := lcd.normalizeUID(uid)
+	if err != nil {
+		return false, err
+	}
+
+	databases, err := lcd.ListDatabases(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range databases.Databases {
+		if d.DatabaseName == db {
+			return Role(GrantOwner).Allows(action), nil
+		}
+	}
+
+	if len(table) > 0 && tableCreatorAllows(action) {
+		tables, err := lcd.ListTables(ctx, "", uid, db, nil, nil)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range tables.Tables {
+			if t.TableName == table && t.Creator == uid {
+				return true, nil
+			}
+		}
+	}
+
+	grants, err := lcd.ListSQLGrants(ctx, db, table)
+	if err != nil {
+		return false, err
+	}
+	for _, g := range grants {
+		if g.UID == uid && g.Role.Allows(action) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// tableCreatorAllows returns whether the creator of a table may perform action on it without a role
+func tableCreatorAllows(action Action) bool {
+	return action == ActionAlter || action == ActionDrop || Role(GrantWriter).Allows(action)
+}
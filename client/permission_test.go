@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListSQLGrants(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	granter, reader, writer := testSigner, lcd.bech32(testAddress(1)), lcd.bech32(testAddress(2))
+	grant := func(uid string, role Role) *glittertypes.SQLGrantRequest {
+		return &glittertypes.SQLGrantRequest{Uid: granter, OnDatabase: "library", ToUID: uid, Role: string(role)}
+	}
+	revoke := func(uid string, role Role) *glittertypes.SQLRevokeRequest {
+		return &glittertypes.SQLRevokeRequest{Uid: granter, OnDatabase: "library", ToUID: uid, Role: string(role)}
+	}
+
+	search := &txSearchServer{t: t, lcd: New("glitter_12000-2", nil)}
+	search.commitMsgs(3, grant(reader, GrantReader), grant(writer, GrantWriter))
+	// revoked and granted again in the same block, by two txs and by the messages of one tx
+	search.commitMsgs(5, revoke(reader, GrantReader))
+	regrant := search.commitMsgs(5, grant(reader, GrantReader))
+	writerTx := search.commitMsgs(7, revoke(writer, GrantWriter), grant(writer, GrantWriter))
+	// granted and revoked in the same block
+	search.commitMsgs(9, grant(reader, GrantWriter))
+	search.commitMsgs(9, revoke(reader, GrantWriter))
+	srv := httptest.NewServer(search)
+	defer srv.Close()
+
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	grants, err := lcd.ListSQLGrants(context.Background(), "library", "")
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, reader, grants[0].UID)
+	assert.Equal(t, Role(GrantReader), grants[0].Role)
+	assert.Equal(t, regrant, grants[0].TxHash)
+	assert.Equal(t, int64(5), grants[0].Height)
+	assert.Equal(t, writer, grants[1].UID)
+	assert.Equal(t, Role(GrantWriter), grants[1].Role)
+	assert.Equal(t, writerTx, grants[1].TxHash)
+}
+
+func Test_CheckPermission(t *testing.T) {
+	search := &txSearchServer{t: t, lcd: New("glitter_12000-2", nil)}
+	creator, tableCreator, reader := testSigner, search.lcd.bech32(testAddress(1)), search.lcd.bech32(testAddress(2))
+	search.commitMsgs(3, &glittertypes.SQLGrantRequest{Uid: creator, OnDatabase: "library", ToUID: reader, Role: string(GrantReader)})
+	mux := http.NewServeMux()
+	mux.Handle("/", search)
+	mux.HandleFunc("/blockved/glitterchain/index/sql/list_databases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"databases":[{"databaseName":"library","creator":"%s"}]}`, creator)
+	})
+	mux.HandleFunc("/blockved/glitterchain/index/sql/list_tables", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tables":[{"tableName":"ebook","creator":"%s"}]}`, tableCreator)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	ctx := context.Background()
+
+	for _, c := range []struct {
+		uid, table string
+		action     Action
+		allowed    bool
+	}{
+		// the database creator may do everything, also on the tables created by others
+		{creator, "ebook", ActionDrop, true},
+		{creator, "ebook", ActionGrant, true},
+		{creator, "", ActionCreate, true},
+		// the table creator may change its table but not grant roles on it
+		{tableCreator, "ebook", ActionAlter, true},
+		{tableCreator, "ebook", ActionDelete, true},
+		{tableCreator, "ebook", ActionGrant, false},
+		{tableCreator, "author", ActionSelect, false},
+		{reader, "ebook", ActionSelect, true},
+		{reader, "ebook", ActionInsert, false},
+	} {
+		allowed, err := lcd.CheckPermission(ctx, c.uid, "library", c.table, c.action)
+		require.NoError(t, err)
+		assert.Equal(t, c.allowed, allowed, "%s %s %s", c.uid, c.table, c.action)
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"gopkg.in/yaml.v3"
+)
+
+// AccessPolicy the roles users should hold on a database and its tables
+//
+//	database: library
+//	grants:
+//	  - uid: glitter1...
+//	    role: admin
+//	  - uid: glitter1...
+//	    table: ebook
+//	    role: writer
+type AccessPolicy struct {
+	Database string        `yaml:"database" json:"database"`
+	Grants   []PolicyGrant `yaml:"grants" json:"grants"`
+}
+
+// PolicyGrant a role held by a user, on the policy database if Table is empty
+type PolicyGrant struct {
+	UID   string `yaml:"uid" json:"uid"`
+	Table string `yaml:"table,omitempty" json:"table,omitempty"`
+	Role  Role   `yaml:"role" json:"role"`
+}
+
+// AccessPlan grants and revokes needed to reconcile the chain with an AccessPolicy
+type AccessPlan struct {
+	Database string
+	Grants   []PolicyGrant
+	Revokes  []PolicyGrant
+}
+
+// ParseAccessPolicy parse and validate a yaml access policy
+func ParseAccessPolicy(bz []byte) (*AccessPolicy, error) {
+	var policy AccessPolicy
+	if err := yaml.Unmarshal(bz, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse access policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate check every grant of the policy applies at its scope
+func (p *AccessPolicy) Validate() error {
+	for i, g := range p.Grants {
+		if len(g.UID) == 0 {
+			return fmt.Errorf("grant %d: uid is required", i)
+		}
+		if err := g.Role.ValidateScope(p.Database, g.Table); err != nil {
+			return fmt.Errorf("grant %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Empty returns whether the chain already matches the policy
+func (p *AccessPlan) Empty() bool {
+	return len(p.Grants) == 0 && len(p.Revokes) == 0
+}
+
+// Msgs returns the revoke and grant messages of the plan signed by the bech32 address granter
+func (p *AccessPlan) Msgs(granter string) []msg.Msg {
+	msgs := make([]msg.Msg, 0, len(p.Revokes)+len(p.Grants))
+	for _, g := range p.Revokes {
+		msgs = append(msgs, newSQLRevokeRequest(granter, p.Database, g.Table, g.UID, g.Role.String()))
+	}
+	for _, g := range p.Grants {
+		msgs = append(msgs, newSQLGrantRequest(granter, p.Database, g.Table, g.UID, g.Role.String()))
+	}
+	return msgs
+}
+
+// planAccessPolicy diff the current roles of the policy database against the policy
+func planAccessPolicy(policy *AccessPolicy, current []*SQLRoleGrant) *AccessPlan {
+	desired := make(map[PolicyGrant]bool, len(policy.Grants))
+	for _, g := range policy.Grants {
+		desired[g] = true
+	}
+	held := make(map[PolicyGrant]bool, len(current))
+	for _, g := range current {
+		if g.Database == policy.Database {
+			held[PolicyGrant{UID: g.UID, Table: g.Table, Role: g.Role}] = true
+		}
+	}
+
+	plan := &AccessPlan{Database: policy.Database}
+	for g := range desired {
+		if !held[g] {
+			plan.Grants = append(plan.Grants, g)
+		}
+	}
+	for g := range held {
+		if !desired[g] {
+			plan.Revokes = append(plan.Revokes, g)
+		}
+	}
+	sortPolicyGrants(plan.Grants)
+	sortPolicyGrants(plan.Revokes)
+	return plan
+}
+
+func sortPolicyGrants(grants []PolicyGrant) {
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.UID != b.UID {
+			return a.UID < b.UID
+		}
+		return a.Role < b.Role
+	})
+}
+
+// PlanAccessPolicy Compute the grants and revokes needed to make the chain match the policy
+// Args:
+//   - policy: Desired roles on the policy database and its tables
+//
+// Returns:
+// The minimal set of grants and revokes, roles held but missing from the policy are revoked
+func (lcd *LCDClient) PlanAccessPolicy(ctx context.Context, policy *AccessPolicy) (*AccessPlan, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	// compare the bech32 uids held on chain with the bech32 form of the policy uids
+	normalized := &AccessPolicy{Database: policy.Database, Grants: make([]PolicyGrant, len(policy.Grants))}
+	for i, g := range policy.Grants {
+		uid, err := lcd.normalizeUID(g.UID)
+		if err != nil {
+			return nil, fmt.Errorf("grant %d: %w", i, err)
+		}
+		g.UID = uid
+		normalized.Grants[i] = g
+	}
+	policy = normalized
+	current, err := lcd.ListSQLGrants(ctx, policy.Database, "")
+	if err != nil {
+		return nil, err
+	}
+	return planAccessPolicy(policy, current), nil
+}
+
+// ApplyAccessPolicy Reconcile the chain with the policy in a single tx
+// Args:
+//   - policy: Desired roles on the policy database and its tables
+//
+// Returns:
+// The applied plan and the result of broadcasting its tx, the response is nil if the plan is empty
+func (lcd *LCDClient) ApplyAccessPolicy(ctx context.Context, policy *AccessPolicy) (*AccessPlan, *sdk.TxResponse, error) {
+	plan, err := lcd.PlanAccessPolicy(ctx, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if plan.Empty() {
+		return plan, nil, nil
+	}
+	resp, err := lcd.SignAndBroadcastTX(ctx, CreateTxOptions{Msgs: plan.Msgs(lcd.Bech32Address())})
+	return plan, resp, err
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseRole(t *testing.T) {
+	r, err := ParseRole("writer")
+	assert.NoError(t, err)
+	assert.Equal(t, Role(GrantWriter), r)
+	assert.True(t, r.Allows(ActionInsert))
+	assert.False(t, r.Allows(ActionDrop))
+	assert.NoError(t, r.ValidateScope("library", "ebook"))
+	assert.Error(t, r.ValidateScope("", "ebook"))
+
+	_, err = ParseRole("wrtier")
+	assert.Error(t, err)
+
+	// admin is only granted on a whole database
+	admin := Role(GrantOwner)
+	assert.NoError(t, admin.ValidateScope("library", ""))
+	assert.EqualError(t, admin.ValidateScope("library", "ebook"), "role admin can not be granted at table scope")
+	_, err = New("glitter_12000-2", nil).GrantAdmin(context.Background(), "library", "ebook", "nobody")
+	assert.EqualError(t, err, "role admin can not be granted at table scope")
+}
+
+func Test_PlanAccessPolicy(t *testing.T) {
+	policy, err := ParseAccessPolicy([]byte(`
+database: library
+grants:
+  - uid: alice
+    role: admin
+  - uid: bob
+    table: ebook
+    role: writer
+  - uid: carol
+    table: ebook
+    role: reader
+`))
+	assert.NoError(t, err)
+
+	current := []*SQLRoleGrant{
+		{Database: "library", UID: "alice", Role: GrantOwner},
+		{Database: "library", Table: "ebook", UID: "bob", Role: GrantReader},
+		{Database: "library", Table: "ebook", UID: "dave", Role: GrantWriter},
+	}
+	plan := planAccessPolicy(policy, current)
+	assert.Equal(t, []PolicyGrant{
+		{UID: "bob", Table: "ebook", Role: GrantWriter},
+		{UID: "carol", Table: "ebook", Role: GrantReader},
+	}, plan.Grants)
+	assert.Equal(t, []PolicyGrant{
+		{UID: "bob", Table: "ebook", Role: GrantReader},
+		{UID: "dave", Table: "ebook", Role: GrantWriter},
+	}, plan.Revokes)
+
+	current = append(current[:1], &SQLRoleGrant{Database: "library", Table: "ebook", UID: "bob", Role: GrantWriter},
+		&SQLRoleGrant{Database: "library", Table: "ebook", UID: "carol", Role: GrantReader})
+	assert.True(t, planAccessPolicy(policy, current).Empty())
+
+	_, err = ParseAccessPolicy([]byte("database: library\ngrants:\n  - uid: alice\n    role: owner\n"))
+	assert.Error(t, err)
+	_, err = ParseAccessPolicy([]byte("database: library\ngrants:\n  - uid: alice\n    table: ebook\n    role: admin\n"))
+	assert.Error(t, err)
+}
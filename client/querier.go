@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 
 	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
@@ -18,9 +21,11 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/glitternetwork/glitter-sdk-go/msg"
 	"github.com/glitternetwork/glitter-sdk-go/tx"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
 	"github.com/glitternetwork/glitter-sdk-go/utils/sqlutil"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context/ctxhttp"
+	"google.golang.org/grpc/codes"
 )
 
 // QueryAccountResData response
@@ -37,7 +42,7 @@ type QueryAccountRes struct {
 
 // LoadAccount simulates gas and fee for a transaction
 func (lcd *LCDClient) LoadAccount(ctx context.Context, address msg.AccAddress) (res authtypes.AccountI, err error) {
-	resp, err := ctxhttp.Get(ctx, lcd.c, lcd.URL+fmt.Sprintf("/cosmos/auth/v1beta1/accounts/%s", address))
+	resp, err := ctxhttp.Get(ctx, lcd.c, lcd.URL+fmt.Sprintf("/cosmos/auth/v1beta1/accounts/%s", lcd.bech32(address)))
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "failed to estimate")
 	}
@@ -48,6 +53,10 @@ func (lcd *LCDClient) LoadAccount(ctx context.Context, address msg.AccAddress) (
 		return nil, sdkerrors.Wrap(err, "failed to read response")
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "account %s not found: %s", lcd.bech32(address), string(out))
+	}
+
 	if resp.StatusCode != 200 {
 		return nil, fmt.Errorf("non-200 response code %d: %s", resp.StatusCode, string(out))
 	}
@@ -103,6 +112,9 @@ func (lcd *LCDClient) Simulate(ctx context.Context, txbuilder tx.Builder, option
 	}
 
 	if resp.StatusCode != 200 {
+		if simulateErr, ok := newSimulateError(resp.StatusCode, out); ok {
+			return nil, simulateErr
+		}
 		return nil, fmt.Errorf("non-200 response code %d: %s", resp.StatusCode, string(out))
 	}
 
@@ -115,6 +127,46 @@ func (lcd *LCDClient) Simulate(ctx context.Context, txbuilder tx.Builder, option
 	return &response, nil
 }
 
+// SimulateError the node rejected the simulated tx, such as a SQL or permission error of the
+// ante handler or msg handler
+type SimulateError struct {
+	StatusCode int
+	Message    string
+	Body       string
+}
+
+// simulateRejection suffix of the message of a tx rejected by the ante handler or msg handler,
+// the tx service of the sdk returns the rejection with gRPC code Unknown, served as status 500
+const simulateRejection = "With gas wanted:"
+
+// newSimulateError returns the SimulateError of a non-200 simulate response, ok is false if the
+// response is not a rejection of the tx, such as a missing endpoint or a failure of the node
+func newSimulateError(statusCode int, body []byte) (*SimulateError, bool) {
+	var status struct {
+		Code    *codes.Code `json:"code"`
+		Message string      `json:"message"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil || status.Code == nil {
+		return nil, false
+	}
+	rejected := statusCode >= 400 && statusCode < 500 && statusCode != http.StatusNotFound
+	if *status.Code == codes.Unknown && strings.Contains(status.Message, simulateRejection) {
+		rejected = true
+	}
+	if !rejected {
+		return nil, false
+	}
+	return &SimulateError{
+		StatusCode: statusCode,
+		Message:    status.Message,
+		Body:       string(body),
+	}, true
+}
+
+func (e *SimulateError) Error() string {
+	return fmt.Sprintf("non-200 response code %d: %s", e.StatusCode, e.Body)
+}
+
 // QueryScan execute a SQL query statement and scan result to target
 // Args:
 //   - target: Target to scan result values
@@ -188,7 +240,7 @@ func (lcd *LCDClient) Query(ctx context.Context, sql string, args ...*glittertyp
 // ListTables List tables in glitter, filtering by various criteria
 // Args:
 //   - tableKeyword: Filter tables by keyword
-//   - uid: Filter tables by creator uid
+//   - uid: Filter tables by creator uid, in the glitter1... or 0x... form
 //   - database: Filter tables by database name
 //   - page: Page number for pagination
 //   - pageSize: Number of results per page
@@ -201,6 +253,10 @@ func (lcd *LCDClient) ListTables(ctx context.Context, tableKeyword, uid, databas
 		uv.Add("keyword", tableKeyword)
 	}
 	if len(uid) > 0 {
+		uid, err := lcd.normalizeUID(uid)
+		if err != nil {
+			return nil, err
+		}
 		uv.Add("uid", uid)
 	}
 	if len(database) > 0 {
@@ -239,11 +295,16 @@ func (lcd *LCDClient) ListTables(ctx context.Context, tableKeyword, uid, databas
 
 // ListDatabases List all databases or filter by creator in glitter
 // Args:
-//   - creator (optional): Only return databases created by this creator
+//   - creator (optional): Only return databases created by this creator, in the glitter1... or 0x... form
 //
 // Returns:
 // ListDatabasesResponse containing matching databases
 func (lcd *LCDClient) ListDatabases(ctx context.Context, creator string) (res *glittertypes.SQLListDatabasesResponse, err error) {
+	if len(creator) > 0 {
+		if creator, err = lcd.normalizeUID(creator); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := ctxhttp.Get(ctx, lcd.c, lcd.URL+"/blockved/glitterchain/index/sql/list_databases")
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "failed to get doc")
@@ -309,3 +370,52 @@ func (lcd *LCDClient) ShowCreateTable(ctx context.Context, database string, tabl
 
 	return &response, nil
 }
+
+// TableSchema Parse the columns of an existing table from its CREATE TABLE statement
+// Args:
+// - database: The database name
+// - table: The table name
+//
+// Returns:
+// The table schema
+func (lcd *LCDClient) TableSchema(ctx context.Context, database string, table string) (*utils.TableSchema, error) {
+	res, err := lcd.ShowCreateTable(ctx, database, table)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := utils.ParseCreateTable(res.Schema)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "failed to parse schema of %s", utils.FullTableName(database, table))
+	}
+	if len(schema.Database) == 0 {
+		schema.Database = database
+	}
+	return schema, nil
+}
+
+// queryJSON send a GET request to the rest endpoint path and unmarshal the json response
+func (lcd *LCDClient) queryJSON(ctx context.Context, path string, response codec.ProtoMarshaler) error {
+	resp, err := ctxhttp.Get(ctx, lcd.c, lcd.URL+path)
+	if err != nil {
+		return sdkerrors.Wrap(err, "failed to query")
+	}
+	defer resp.Body.Close()
+
+	out, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return sdkerrors.Wrap(err, "failed to read response")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return sdkerrors.Wrapf(sdkerrors.ErrNotFound, "non-200 response code %d: %s", resp.StatusCode, string(out))
+	}
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("non-200 response code %d: %s", resp.StatusCode, string(out))
+	}
+
+	err = lcd.GetMarshaler().UnmarshalJSON(out, response)
+	if err != nil {
+		return sdkerrors.Wrap(err, "failed to unmarshal response")
+	}
+	return nil
+}
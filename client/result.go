@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// ExecResult decoded result of a committed SQL execution tx
+type ExecResult struct {
+	// TxHash hash of the tx, empty for a dry run
+	TxHash string
+	// Height block height of the tx, 0 for a dry run
+	Height int64
+	// Responses decoded SQLExecResponse of every SQLExecRequest of the tx, the result the chain
+	// returns for each statement
+	Responses []*glittertypes.SQLExecResponse
+	// Events events emitted by the tx
+	Events sdk.StringEvents
+}
+
+// NewExecResult decode the ExecResult of a committed tx response, the SQLExecResponse of every
+// statement is decoded from the TxMsgData of the tx data
+func NewExecResult(txResponse *sdk.TxResponse) (*ExecResult, error) {
+	data, err := hex.DecodeString(txResponse.Data)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx data")
+	}
+	responses, err := decodeSQLExecResponses(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ExecResult{
+		TxHash:    txResponse.TxHash,
+		Height:    txResponse.Height,
+		Responses: responses,
+	}
+	for _, log := range txResponse.Logs {
+		res.Events = append(res.Events, log.Events...)
+	}
+	return res, nil
+}
+
+// SQLExecResult Execute a SQL and wait until its tx is committed
+// Args:
+//   - options: Tx options
+//   - sql: SQL statement to execute
+//   - args: Parameters of the SQL statement, default to None
+//
+// Returns:
+// The raw response of the committed tx and its decoded ExecResult,
+// in dry run mode the simulated response is decoded without waiting
+func (lcd *LCDClient) SQLExecResult(ctx context.Context, options CreateTxOptions, sql string, args []*glittertypes.Argument) (*sdk.TxResponse, *ExecResult, error) {
+	_msg := lcd.newSQLExecRequest(sql, args)
+	options.Msgs = []msg.Msg{_msg}
+	return lcd.signBroadcastAndWait(ctx, options)
+}
+
+// signBroadcastAndWait broadcast the tx of options and decode the ExecResult once it is committed
+func (lcd *LCDClient) signBroadcastAndWait(ctx context.Context, options CreateTxOptions) (*sdk.TxResponse, *ExecResult, error) {
+	txResponse, err := lcd.SignAndBroadcastTX(ctx, options)
+	if err != nil {
+		return txResponse, nil, err
+	}
+	if !lcd.dryRun {
+		committed, err := lcd.WaitTx(ctx, txResponse.TxHash)
+		if err != nil {
+			return txResponse, nil, sdkerrors.Wrap(err, "failed to wait tx")
+		}
+		lcd.observeGasUsed(committed)
+		txResponse = committed.TxResponse
+		if txResponse.Code != 0 {
+			return txResponse, nil, fmt.Errorf("tx failed with code %d: %s", txResponse.Code, txResponse.RawLog)
+		}
+	}
+	res, err := NewExecResult(txResponse)
+	return txResponse, res, err
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewExecResult(t *testing.T) {
+	responseData, err := proto.Marshal(&glittertypes.SQLExecResponse{})
+	assert.NoError(t, err)
+	txMsgData, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{
+		{MsgType: "/cosmos.bank.v1beta1.MsgSend"},
+		{MsgType: SQLExecMsgTypeURL, Data: responseData},
+	}})
+	assert.NoError(t, err)
+
+	res, err := NewExecResult(&sdk.TxResponse{
+		TxHash: "ABCD",
+		Height: 42,
+		Data:   hex.EncodeToString(txMsgData),
+		Logs: sdk.ABCIMessageLogs{{Events: sdk.StringEvents{{
+			Type:       "message",
+			Attributes: []sdk.Attribute{{Key: "action", Value: SQLExecMsgTypeURL}},
+		}}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD", res.TxHash)
+	assert.Equal(t, int64(42), res.Height)
+	// only the responses of SQLExecRequest are decoded
+	assert.Equal(t, []*glittertypes.SQLExecResponse{{}}, res.Responses)
+	assert.Len(t, res.Events, 1)
+
+	res, err = NewExecResult(&sdk.TxResponse{TxHash: "ABCD"})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Responses)
+
+	_, err = NewExecResult(&sdk.TxResponse{TxHash: "ABCD", Data: "not hex"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"fmt"
+)
+
+// Role SQL role granted on a database or table
+type Role string
+
+// Scope level a role is granted at
+type Scope string
+
+// Action SQL action a role may perform
+type Action string
+
+// The roles are untyped so they are accepted both as Role and as the role string of SQLGrant and SQLRevoke
+const (
+	// GrantReader may select rows of a database or table
+	GrantReader = "reader"
+	// GrantWriter may select, insert, update and delete rows of a database or table
+	GrantWriter = "writer"
+	// GrantOwner may do everything a writer does, create, alter and drop tables and grant roles,
+	// it is only granted on a whole database
+	GrantOwner = "admin"
+)
+
+const (
+	// ScopeDatabase role applies to every table of the database
+	ScopeDatabase Scope = "database"
+	// ScopeTable role applies to one table
+	ScopeTable Scope = "table"
+)
+
+// SQL actions checked by CheckPermission
+const (
+	ActionSelect Action = "select"
+	ActionInsert Action = "insert"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionCreate Action = "create"
+	ActionAlter  Action = "alter"
+	ActionDrop   Action = "drop"
+	ActionGrant  Action = "grant"
+)
+
+// roleCapabilities scopes each role can be granted at and the actions it allows
+var roleCapabilities = map[Role]struct {
+	scopes  []Scope
+	actions []Action
+}{
+	GrantReader: {
+		scopes:  []Scope{ScopeDatabase, ScopeTable},
+		actions: []Action{ActionSelect},
+	},
+	GrantWriter: {
+		scopes:  []Scope{ScopeDatabase, ScopeTable},
+		actions: []Action{ActionSelect, ActionInsert, ActionUpdate, ActionDelete},
+	},
+	GrantOwner: {
+		scopes:  []Scope{ScopeDatabase},
+		actions: []Action{ActionSelect, ActionInsert, ActionUpdate, ActionDelete, ActionCreate, ActionAlter, ActionDrop, ActionGrant},
+	},
+}
+
+// ParseRole parse and validate a role name
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	return r, r.Validate()
+}
+
+// String returns the role name used on chain
+func (r Role) String() string {
+	return string(r)
+}
+
+// Validate check the role is known
+func (r Role) Validate() error {
+	if _, ok := roleCapabilities[r]; !ok {
+		return fmt.Errorf("unknown role: %q", string(r))
+	}
+	return nil
+}
+
+// Scopes returns the scopes the role can be granted at
+func (r Role) Scopes() []Scope {
+	return roleCapabilities[r].scopes
+}
+
+// Actions returns the actions the role allows
+func (r Role) Actions() []Action {
+	return roleCapabilities[r].actions
+}
+
+// Allows returns whether the role allows action
+func (r Role) Allows(action Action) bool {
+	for _, a := range r.Actions() {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScope check the role can be granted on database, or on table if not empty
+func (r Role) ValidateScope(database, table string) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if len(database) == 0 {
+		return fmt.Errorf("database is required to grant role %s", r)
+	}
+	scope := ScopeDatabase
+	if len(table) > 0 {
+		scope = ScopeTable
+	}
+	for _, s := range r.Scopes() {
+		if s == scope {
+			return nil
+		}
+	}
+	return fmt.Errorf("role %s can not be granted at %s scope", r, scope)
+}
+
+// Validate check the action is known
+func (a Action) Validate() error {
+	for _, c := range roleCapabilities[GrantOwner].actions {
+		if c == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action: %q", string(a))
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SequenceManager hand out the sequences of the client account so txs can be sent without
+// waiting for the previous one to be committed. Txs are signed and broadcast one at a time
+// in sequence order, waiting for their commit can be done concurrently.
+type SequenceManager struct {
+	lcd *LCDClient
+
+	mu            sync.Mutex
+	loaded        bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+// NewSequenceManager create sequence manager of the client account
+func NewSequenceManager(lcd *LCDClient) *SequenceManager {
+	return &SequenceManager{lcd: lcd}
+}
+
+// Sequence returns the sequence the next tx is signed with, 0 if not loaded yet
+func (m *SequenceManager) Sequence() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sequence
+}
+
+// Reset reload the account number and sequence from the chain before the next tx
+func (m *SequenceManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loaded = false
+}
+
+// SignAndBroadcastTX sign the tx of options with the next sequence and broadcast it,
+// the sequence is reloaded from the chain after a failed broadcast
+func (m *SequenceManager) SignAndBroadcastTX(ctx context.Context, options CreateTxOptions) (*sdk.TxResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.loaded {
+		account, err := m.lcd.LoadAccount(ctx, m.lcd.GetAddress())
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to load account")
+		}
+		m.accountNumber = account.GetAccountNumber()
+		m.sequence = account.GetSequence()
+		m.loaded = true
+	}
+
+	options.AccountNumber = m.accountNumber
+	options.Sequence = m.sequence
+	txResponse, err := m.lcd.SignAndBroadcastTX(ctx, options)
+	if err != nil {
+		m.loaded = false
+		return txResponse, err
+	}
+	if !m.lcd.dryRun {
+		m.sequence++
+	}
+	return txResponse, nil
+}
@@ -0,0 +1,583 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// subscribeMinBackoff and subscribeMaxBackoff bound the wait before a reconnection
+	subscribeMinBackoff = time.Second
+	subscribeMaxBackoff = 30 * time.Second
+	// subscribeReadTimeout max silence of the websocket, the node pings every 27s
+	subscribeReadTimeout  = time.Minute
+	subscribeWriteTimeout = 10 * time.Second
+)
+
+// sqlExecActions message actions of the txs executing SQL, directly or by a MsgExec as sent by
+// SQLExecOnBehalfOf
+var sqlExecActions = []string{SQLExecMsgTypeURL, sdk.MsgTypeURL(&authz.MsgExec{})}
+
+// SQLEvent a SQLExecRequest committed on chain
+type SQLEvent struct {
+	Height int64
+	TxHash string
+	// Time block time of the tx, zero for the txs received live by Subscribe
+	Time time.Time
+	// MsgIndex index of the message among the SQLExecRequest of the tx, the ones authorized by
+	// a MsgExec included
+	MsgIndex int
+	// Signer uid of the message
+	Signer string
+	// Database and Table written by the statement, Database is empty if the table is not qualified
+	Database string
+	Table    string
+	// StatementType upper cased leading keyword of the statement, such as INSERT
+	StatementType string
+	Message       *glittertypes.SQLExecRequest
+	// Response response of the message, nil if the chain does not report it
+	Response *glittertypes.SQLExecResponse
+	// Result result of the whole tx, shared by the events of the tx
+	Result *ExecResult
+}
+
+// SQLEventFilter selects the SQL events, the empty fields match any event
+type SQLEventFilter struct {
+	// Database database written, matched case-insensitively
+	Database string
+	// Table table written, matched case-insensitively
+	Table string
+	// Signer uid of the message, the glitter1... or 0x... form
+	Signer string
+	// StatementTypes leading keywords of the statements, such as INSERT or UPDATE
+	StatementTypes []string
+	// FromHeight height to replay the committed txs from, 0 streams from the latest block
+	// for Subscribe and replays from the first block for CDCReader
+	FromHeight int64
+}
+
+// Match returns whether e is selected by f
+func (f *SQLEventFilter) Match(e *SQLEvent) bool {
+	if len(f.Database) > 0 && !strings.EqualFold(f.Database, e.Database) {
+		return false
+	}
+	if len(f.Table) > 0 && !strings.EqualFold(f.Table, e.Table) {
+		return false
+	}
+	if len(f.Signer) > 0 && f.Signer != e.Signer {
+		return false
+	}
+	if len(f.StatementTypes) > 0 {
+		for _, t := range f.StatementTypes {
+			if strings.EqualFold(t, e.StatementType) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// newSQLEvents decode the events of the SQLExecRequest in msgs of a committed tx, directly or
+// authorized by a MsgExec, failed txs have no event
+func newSQLEvents(msgs []sdk.Msg, txResponse *sdk.TxResponse) ([]*SQLEvent, error) {
+	if txResponse.Code != 0 {
+		return nil, nil
+	}
+	result, err := NewExecResult(txResponse)
+	if err != nil {
+		return nil, err
+	}
+	var blockTime time.Time
+	if len(txResponse.Timestamp) > 0 {
+		if blockTime, err = time.Parse(time.RFC3339Nano, txResponse.Timestamp); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to parse tx timestamp")
+		}
+	}
+	data, err := hex.DecodeString(txResponse.Data)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx data")
+	}
+	var txMsgData sdk.TxMsgData
+	if err := txMsgData.Unmarshal(data); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to decode tx msg data")
+	}
+
+	var events []*SQLEvent
+	// responded tells whether the tx data holds the response of exec, an empty response encodes to no byte
+	add := func(exec *glittertypes.SQLExecRequest, responseData []byte, responded bool) error {
+		e := &SQLEvent{
+			Height:        txResponse.Height,
+			TxHash:        txResponse.TxHash,
+			Time:          blockTime,
+			MsgIndex:      len(events),
+			Signer:        exec.Uid,
+			StatementType: utils.StatementKind(exec.Sql),
+			Message:       exec,
+			Result:        result,
+		}
+		e.Database, e.Table = utils.StatementTarget(exec.Sql)
+		if responded {
+			e.Response = &glittertypes.SQLExecResponse{}
+			if err := proto.Unmarshal(responseData, e.Response); err != nil {
+				return sdkerrors.Wrap(err, "failed to decode sql exec response")
+			}
+		}
+		events = append(events, e)
+		return nil
+	}
+	// the data of a message is at its index, the data of a MsgExec holds the data of its messages
+	for i, m := range msgs {
+		var responseData []byte
+		responded := i < len(txMsgData.Data)
+		if responded {
+			responseData = txMsgData.Data[i].Data
+		}
+		switch m := m.(type) {
+		case *glittertypes.SQLExecRequest:
+			if err := add(m, responseData, responded); err != nil {
+				return nil, err
+			}
+		case *authz.MsgExec:
+			authorized, err := m.GetMessages()
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "failed to unpack authorized messages")
+			}
+			var execResponse authz.MsgExecResponse
+			if err := execResponse.Unmarshal(responseData); err != nil {
+				return nil, sdkerrors.Wrap(err, "failed to decode exec response")
+			}
+			for j, authorizedMsg := range authorized {
+				exec, ok := authorizedMsg.(*glittertypes.SQLExecRequest)
+				if !ok {
+					continue
+				}
+				var authorizedData []byte
+				if j < len(execResponse.Results) {
+					authorizedData = execResponse.Results[j]
+				}
+				if err := add(exec, authorizedData, j < len(execResponse.Results)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return events, nil
+}
+
+// Subscription stream of the SQL events of Subscribe
+type Subscription struct {
+	events chan *SQLEvent
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// Events returns the channel of the events, it is closed when the subscription ends
+func (s *Subscription) Events() <-chan *SQLEvent {
+	return s.events
+}
+
+// Err returns the error ending the subscription after Events is closed, nil if it is closed by Close
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close end the subscription and wait for its connection to close
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *Subscription) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// subscriber state of a running subscription
+type subscriber struct {
+	lcd    *LCDClient
+	url    string
+	filter SQLEventFilter
+	sub    *Subscription
+
+	// height lowest height whose txs are not all emitted, seen holds the emitted txs of it
+	height int64
+	seen   map[string]bool
+}
+
+// Subscribe Stream the committed SQLExecRequest, direct or executed on behalf of the signer by a MsgExec,
+// over the websocket of the tendermint rpc endpoint, the connection is reopened on failures and the txs committed meanwhile are replayed by tx search,
+// so no event is missed or repeated
+// Args:
+//   - filter: selects the streamed events
+//
+// Returns:
+// The subscription, the events of a tx are streamed in message order and the txs in commit order
+func (lcd *LCDClient) Subscribe(ctx context.Context, filter SQLEventFilter) (*Subscription, error) {
+	wsURL, err := websocketURL(lcd.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.Signer) > 0 {
+		if filter.Signer, err = lcd.normalizeUID(filter.Signer); err != nil {
+			return nil, err
+		}
+	}
+
+	height := filter.FromHeight
+	if height <= 0 {
+		var latest tmservice.GetLatestBlockResponse
+		if err := lcd.queryJSON(ctx, "/cosmos/base/tendermint/v1beta1/blocks/latest", &latest); err != nil {
+			return nil, err
+		}
+		if latest.Block == nil {
+			return nil, fmt.Errorf("node %s reports no latest block", lcd.URL)
+		}
+		height = latest.Block.Header.Height + 1
+	}
+
+	conn, err := dialSubscription(ctx, wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &subscriber{
+		lcd:    lcd,
+		url:    wsURL,
+		filter: filter,
+		sub:    &Subscription{events: make(chan *SQLEvent), cancel: cancel, done: make(chan struct{})},
+		height: height,
+		seen:   map[string]bool{},
+	}
+	go s.run(ctx, conn)
+	return s.sub, nil
+}
+
+// run stream the events until ctx is done, reconnecting with backoff
+func (s *subscriber) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(s.sub.done)
+	defer close(s.sub.events)
+
+	backoff := subscribeMinBackoff
+	for {
+		if conn != nil {
+			err := s.stream(ctx, conn)
+			conn.Close()
+			if ctx.Err() != nil {
+				return
+			}
+			if _, ok := err.(*permanentError); ok {
+				s.sub.setErr(err)
+				return
+			}
+			backoff = subscribeMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > subscribeMaxBackoff {
+			backoff = subscribeMaxBackoff
+		}
+
+		var err error
+		if conn, err = dialSubscription(ctx, s.url); err != nil {
+			if _, ok := err.(*permanentError); ok {
+				s.sub.setErr(err)
+				return
+			}
+			conn = nil
+		}
+	}
+}
+
+// stream replay the txs from s.height by tx search, then the txs pushed to conn,
+// the txs committed during the replay are queued by conn
+func (s *subscriber) stream(ctx context.Context, conn *websocket.Conn) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	events := []string{fmt.Sprintf("tx.height>=%d", s.height)}
+	err := s.lcd.walkActionTxs(ctx, sqlExecActions, events, func(t *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		return s.emit(ctx, t.GetMsgs(), txResponse)
+	})
+	if err != nil {
+		return err
+	}
+
+	decode := s.lcd.GetTxConfig().TxDecoder()
+	for {
+		txResponse, txBytes, err := readTxResult(conn)
+		if err != nil {
+			return err
+		}
+		t, err := decode(txBytes)
+		if err != nil {
+			return &permanentError{sdkerrors.Wrap(err, "failed to decode tx")}
+		}
+		if err := s.emit(ctx, t.GetMsgs(), txResponse); err != nil {
+			return err
+		}
+	}
+}
+
+// emit send the selected events of a tx unless it is emitted already
+func (s *subscriber) emit(ctx context.Context, msgs []sdk.Msg, txResponse *sdk.TxResponse) error {
+	switch {
+	case txResponse.Height < s.height:
+		return nil
+	case txResponse.Height > s.height:
+		s.height = txResponse.Height
+		s.seen = map[string]bool{}
+	}
+	if s.seen[txResponse.TxHash] {
+		return nil
+	}
+
+	events, err := newSQLEvents(msgs, txResponse)
+	if err != nil {
+		return &permanentError{err}
+	}
+	for _, e := range events {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.sub.events <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.seen[txResponse.TxHash] = true
+	return nil
+}
+
+// rpcResponse json-rpc response of the tendermint websocket
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcError error response of the tendermint websocket, such as a rejected subscribe request or a
+// subscription the node cancelled because the client is not pulling messages fast enough
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s %s", e.Code, e.Message, e.Data)
+}
+
+// rpcTxEvent result of a tx event of the tendermint websocket
+type rpcTxEvent struct {
+	Data struct {
+		Value struct {
+			TxResult struct {
+				Height string `json:"height"`
+				Tx     []byte `json:"tx"`
+				Result struct {
+					Code      uint32 `json:"code"`
+					Codespace string `json:"codespace"`
+					Data      []byte `json:"data"`
+					Log       string `json:"log"`
+					GasWanted string `json:"gas_wanted"`
+					GasUsed   string `json:"gas_used"`
+				} `json:"result"`
+			} `json:"TxResult"`
+		} `json:"value"`
+	} `json:"data"`
+	// Events the attributes of the events of the tx by their type.key
+	Events map[string][]string `json:"events"`
+}
+
+// permanentError an error the subscription ends with instead of reconnecting,
+// such as a subscribe request the node rejects or an undecodable tx
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// dialSubscription open the websocket of url and subscribe to the txs, the query cannot select the txs
+// of any of sqlExecActions and two subscriptions would not keep the commit order, so it subscribes to all
+// txs and readTxResult skips the others
+func dialSubscription(ctx context.Context, url string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to connect websocket")
+	}
+	conn.SetPingHandler(func(data string) error {
+		if err := conn.SetReadDeadline(time.Now().Add(subscribeReadTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(subscribeWriteTimeout))
+	})
+
+	request := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "subscribe",
+		"id":      1,
+		"params":  map[string]string{"query": "tm.event='Tx'"},
+	}
+	if err := conn.SetWriteDeadline(time.Now().Add(subscribeWriteTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.WriteJSON(request); err != nil {
+		conn.Close()
+		return nil, sdkerrors.Wrap(err, "failed to subscribe")
+	}
+	// the first response acknowledges the subscription, the node rejecting it would reject it again
+	if _, err := readRPCResult(conn); err != nil {
+		conn.Close()
+		var rejected *rpcError
+		if errors.As(err, &rejected) {
+			return nil, &permanentError{err}
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// readRPCResult read the result of the next json-rpc response of conn, an error response is returned
+// as *rpcError
+func readRPCResult(conn *websocket.Conn) (json.RawMessage, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(subscribeReadTimeout)); err != nil {
+		return nil, err
+	}
+	var response rpcResponse
+	if err := conn.ReadJSON(&response); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to read websocket")
+	}
+	if response.Error != nil {
+		return nil, response.Error
+	}
+	return response.Result, nil
+}
+
+// readTxResult read the next tx event of conn
+func readTxResult(conn *websocket.Conn) (*sdk.TxResponse, []byte, error) {
+	for {
+		result, err := readRPCResult(conn)
+		if err != nil {
+			return nil, nil, err
+		}
+		txResponse, txBytes, err := decodeTxEvent(result)
+		if err != nil {
+			return nil, nil, &permanentError{err}
+		}
+		if txBytes != nil {
+			return txResponse, txBytes, nil
+		}
+	}
+}
+
+// decodeTxEvent decode the tx response and the raw tx of a tx event, the tx is nil for other results
+// and for the txs without a message of sqlExecActions
+func decodeTxEvent(result json.RawMessage) (*sdk.TxResponse, []byte, error) {
+	var event rpcTxEvent
+	if err := json.Unmarshal(result, &event); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "failed to unmarshal tx event")
+	}
+	txResult := event.Data.Value.TxResult
+	if len(txResult.Tx) == 0 || !hasAnyAction(event.Events["message.action"], sqlExecActions) {
+		return nil, nil, nil
+	}
+
+	height, err := strconv.ParseInt(txResult.Height, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid tx event height %q", txResult.Height)
+	}
+	gasWanted, _ := strconv.ParseInt(txResult.Result.GasWanted, 10, 64)
+	gasUsed, _ := strconv.ParseInt(txResult.Result.GasUsed, 10, 64)
+	// the log of a failed tx is not json
+	logs, _ := sdk.ParseABCILogs(txResult.Result.Log)
+
+	return &sdk.TxResponse{
+		Height:    height,
+		TxHash:    txHash(txResult.Tx),
+		Codespace: txResult.Result.Codespace,
+		Code:      txResult.Result.Code,
+		Data:      strings.ToUpper(hex.EncodeToString(txResult.Result.Data)),
+		RawLog:    txResult.Result.Log,
+		Logs:      logs,
+		GasWanted: gasWanted,
+		GasUsed:   gasUsed,
+	}, txResult.Tx, nil
+}
+
+// hasAnyAction returns whether any of the message actions of a tx is one of actions
+func hasAnyAction(txActions []string, actions []string) bool {
+	for _, a := range txActions {
+		for _, action := range actions {
+			if a == action {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// websocketURL returns the websocket url of the tendermint rpc endpoint
+func websocketURL(rpcEndpoint string) (string, error) {
+	if len(rpcEndpoint) == 0 {
+		return "", fmt.Errorf("rpc endpoint is not set, set it by WithRPCEndpoint")
+	}
+	u, err := url.Parse(rpcEndpoint)
+	if err != nil {
+		return "", sdkerrors.Wrap(err, "invalid rpc endpoint")
+	}
+	switch u.Scheme {
+	case "http", "ws", "":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid rpc endpoint %s", rpcEndpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	return u.String(), nil
+}
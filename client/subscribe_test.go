@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigner = "glitter1q5t6prazp4wlzegvaz5sls25phyvvmq6aqpxf7"
+
+// txEventJSON json-rpc message of the tendermint websocket pushing a committed tx of msgs
+func txEventJSON(t *testing.T, lcd *LCDClient, height int64, msgs ...sdk.Msg) string {
+	txb := lcd.GetTxConfig().NewTxBuilder()
+	require.NoError(t, txb.SetMsgs(msgs...))
+	txBytes, err := lcd.GetTxConfig().TxEncoder()(txb.GetTx())
+	require.NoError(t, err)
+	var actions []string
+	for _, m := range msgs {
+		actions = append(actions, sdk.MsgTypeURL(m))
+	}
+	actionsJSON, err := json.Marshal(actions)
+	require.NoError(t, err)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"data":{"type":"tendermint/event/Tx","value":{"TxResult":`+
+		`{"height":"%d","index":0,"tx":"%s","result":{"data":"%s","log":"[]","gas_wanted":"200000","gas_used":"100000"}}}},`+
+		`"events":{"message.action":%s,"tm.event":["Tx"]}}}`,
+		height, base64.StdEncoding.EncodeToString(txBytes), base64.StdEncoding.EncodeToString(testTxMsgData(t, msgs...)), actionsJSON)
+}
+
+func Test_Subscribe(t *testing.T) {
+	lcd := New("glitter_12000-2", nil)
+	first := txEventJSON(t, lcd, 5, testSQLMsgs("insert into library.ebook values (1)", "update library.author set name='a'")...)
+	// the websocket pushes every tx, the txs of neither SQL action are skipped
+	send := txEventJSON(t, lcd, 6, msg.NewMsgSend(testAddress(1), testAddress(2), msg.NewCoins(msg.NewInt64Coin("agli", 1))))
+	exec := authz.NewMsgExec(testAddress(1), testSQLMsgs("INSERT INTO `library`.`ebook` VALUES (2)"))
+	second := txEventJSON(t, lcd, 6, &exec)
+
+	var mu sync.Mutex
+	var searches []string
+	connections := 0
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/tx/v1beta1/txs":
+			mu.Lock()
+			searches = append(searches, strings.Join(r.URL.Query()["events"], " AND "))
+			mu.Unlock()
+			w.Write([]byte(`{"txs":[],"tx_responses":[]}`))
+		case "/websocket":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			var request map[string]interface{}
+			if conn.ReadJSON(&request) != nil || request["method"] != "subscribe" {
+				return
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+
+			mu.Lock()
+			connections++
+			n := connections
+			mu.Unlock()
+			// the node cancels the first subscription after a tx, the second repeats it before the next ones
+			conn.WriteMessage(websocket.TextMessage, []byte(first))
+			if n == 1 {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"Server error",`+
+					`"data":"subscription was cancelled (reason: client is not pulling messages fast enough)"}}`))
+			} else {
+				conn.WriteMessage(websocket.TextMessage, []byte(send))
+				conn.WriteMessage(websocket.TextMessage, []byte(second))
+			}
+			conn.ReadMessage()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	lcd = New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithRPCEndpoint(srv.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sub, err := lcd.Subscribe(ctx, SQLEventFilter{
+		Table:          "EBOOK",
+		Signer:         "0x0517a08fa20d5Df1650cE8a90Fc1540dc8c66c1A",
+		StatementTypes: []string{"insert"},
+		FromHeight:     3,
+	})
+	require.NoError(t, err)
+
+	var events []*SQLEvent
+	for e := range sub.Events() {
+		events = append(events, e)
+		if len(events) == 2 {
+			sub.Close()
+		}
+	}
+	assert.NoError(t, sub.Err())
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(5), events[0].Height)
+	assert.Equal(t, "library", events[0].Database)
+	assert.Equal(t, "ebook", events[0].Table)
+	assert.Equal(t, "INSERT", events[0].StatementType)
+	assert.Equal(t, testSigner, events[0].Signer)
+	assert.Equal(t, 0, events[0].MsgIndex)
+	assert.NotNil(t, events[0].Response)
+	assert.Len(t, events[0].Result.Responses, 2)
+	assert.Len(t, events[0].TxHash, 64)
+	// the SQL the client executed on behalf of the signer
+	assert.Equal(t, int64(6), events[1].Height)
+	assert.Equal(t, testSigner, events[1].Signer)
+	assert.Equal(t, "(2)", strings.TrimPrefix(events[1].Message.Sql, "INSERT INTO `library`.`ebook` VALUES "))
+	assert.NotNil(t, events[1].Response)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, connections)
+	require.Len(t, searches, 4)
+	for i, search := range searches {
+		assert.Contains(t, search, fmt.Sprintf("message.action='%s'", sqlExecActions[i%2]))
+	}
+	assert.Contains(t, searches[0], "tx.height>=3")
+	assert.Contains(t, searches[2], "tx.height>=5", "resumed from the last streamed height")
+}
+
+func Test_SubscribeRejected(t *testing.T) {
+	connections := 0
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/tx/v1beta1/txs":
+			w.Write([]byte(`{"txs":[],"tx_responses":[]}`))
+		case "/websocket":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			var request map[string]interface{}
+			if conn.ReadJSON(&request) != nil {
+				return
+			}
+			// the first connection drops, the node rejects the subscribe request of the second
+			connections++
+			if connections == 1 {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+				return
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Internal error",`+
+				`"data":"max_subscriptions_per_client 5 reached"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL), WithRPCEndpoint(srv.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sub, err := lcd.Subscribe(ctx, SQLEventFilter{FromHeight: 3})
+	require.NoError(t, err)
+	for range sub.Events() {
+	}
+	var rejected *rpcError
+	require.True(t, errors.As(sub.Err(), &rejected))
+	assert.Equal(t, "max_subscriptions_per_client 5 reached", rejected.Data)
+	assert.Equal(t, 2, connections)
+}
+
+func Test_WebsocketURL(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"http://127.0.0.1:26657":           "ws://127.0.0.1:26657/websocket",
+		"https://rpc.example.com/glitter/": "wss://rpc.example.com/glitter/websocket",
+	} {
+		u, err := websocketURL(endpoint)
+		require.NoError(t, err, endpoint)
+		assert.Equal(t, expected, u)
+	}
+	_, err := websocketURL("")
+	assert.Error(t, err)
+	_, err = websocketURL("tcp://127.0.0.1:26657")
+	assert.Error(t, err)
+}
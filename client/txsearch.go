@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// defaultSearchPageSize page size used when walking all txs of a search
+const defaultSearchPageSize = 100
+
+// waitTxInterval interval between polls of WaitTx
+const waitTxInterval = time.Second
+
+// GetTx Query a committed tx by hash
+// Args:
+//   - hash: Hex encoded tx hash
+//
+// Returns:
+// The tx and its response
+func (lcd *LCDClient) GetTx(ctx context.Context, hash string) (*txtypes.GetTxResponse, error) {
+	var response txtypes.GetTxResponse
+	if err := lcd.queryJSON(ctx, "/cosmos/tx/v1beta1/txs/"+hash, &response); err != nil {
+		return nil, err
+	}
+	if err := lcd.unpackTx(response.Tx); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// observeGasUsed feed the gas used by a tx the client broadcast back to the client gas strategy,
+// txs of other clients are not observed as their gas limits are not decided by the strategy
+func (lcd *LCDClient) observeGasUsed(committed *txtypes.GetTxResponse) {
+	if committed.Tx != nil && committed.TxResponse != nil && committed.TxResponse.Code == 0 {
+		lcd.gasStrategy.ObserveGasUsed(committed.Tx.GetMsgs(), uint64(committed.TxResponse.GasUsed))
+	}
+}
+
+// WaitTx Wait until the tx of hash is committed
+// Args:
+//   - hash: Hex encoded tx hash, such as the TxHash of a broadcast response
+//
+// Returns:
+// The committed tx and its response, ctx bounds the wait
+func (lcd *LCDClient) WaitTx(ctx context.Context, hash string) (*txtypes.GetTxResponse, error) {
+	ticker := time.NewTicker(waitTxInterval)
+	defer ticker.Stop()
+	for {
+		response, err := lcd.GetTx(ctx, hash)
+		if err == nil {
+			return response, nil
+		}
+		if !errors.Is(err, sdkerrors.ErrNotFound) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SearchTxs Search committed txs by events
+// Args:
+//   - events: Event conditions joined by AND, such as "message.action='/cosmos.bank.v1beta1.MsgSend'"
+//   - offset: Number of txs to skip
+//   - limit: Max number of txs to return
+//   - desc: Return newest txs first if true
+//
+// Returns:
+// The matching txs with their responses
+func (lcd *LCDClient) SearchTxs(ctx context.Context, events []string, offset, limit uint64, desc bool) (*txtypes.GetTxsEventResponse, error) {
+	uv := url.Values{}
+	for _, e := range events {
+		uv.Add("events", e)
+	}
+	uv.Add("pagination.offset", strconv.FormatUint(offset, 10))
+	uv.Add("pagination.limit", strconv.FormatUint(limit, 10))
+	if desc {
+		uv.Add("order_by", txtypes.OrderBy_ORDER_BY_DESC.String())
+	} else {
+		uv.Add("order_by", txtypes.OrderBy_ORDER_BY_ASC.String())
+	}
+
+	var response txtypes.GetTxsEventResponse
+	if err := lcd.queryJSON(ctx, "/cosmos/tx/v1beta1/txs?"+uv.Encode(), &response); err != nil {
+		return nil, err
+	}
+	for _, t := range response.Txs {
+		if err := lcd.unpackTx(t); err != nil {
+			return nil, err
+		}
+	}
+	return &response, nil
+}
+
+// unpackTx cache the messages of a tx of a query response, the query responses of the sdk
+// do not unpack the txs they hold, so GetMsgs would panic
+func (lcd *LCDClient) unpackTx(t *txtypes.Tx) error {
+	if t == nil {
+		return nil
+	}
+	if err := t.UnpackInterfaces(lcd.EncodingConfig.InterfaceRegistry); err != nil {
+		return sdkerrors.Wrap(err, "failed to unpack tx")
+	}
+	return nil
+}
+
+// txPager pages through the txs matching events from the oldest to the newest
+type txPager struct {
+	lcd    *LCDClient
+	events []string
+
+	offset    uint64
+	txs       []*txtypes.Tx
+	responses []*sdk.TxResponse
+	done      bool
+}
+
+// peek returns the next tx without consuming it, nil after the last one
+func (p *txPager) peek(ctx context.Context) (*txtypes.Tx, *sdk.TxResponse, error) {
+	for len(p.txs) == 0 && !p.done {
+		response, err := p.lcd.SearchTxs(ctx, p.events, p.offset, defaultSearchPageSize, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.offset += uint64(len(response.Txs))
+		p.txs, p.responses = response.Txs, response.TxResponses
+		// the node rejects a page past the last one, so stop at the total when it is reported
+		p.done = len(response.Txs) < defaultSearchPageSize ||
+			(response.Pagination != nil && p.offset >= response.Pagination.Total)
+	}
+	if len(p.txs) == 0 {
+		return nil, nil, nil
+	}
+	return p.txs[0], p.responses[0], nil
+}
+
+// next consume the tx returned by peek
+func (p *txPager) next() {
+	p.txs, p.responses = p.txs[1:], p.responses[1:]
+}
+
+// walkTxs call fn on every tx matching events from the oldest to the newest
+func (lcd *LCDClient) walkTxs(ctx context.Context, events []string, fn func(tx *txtypes.Tx, txResponse *sdk.TxResponse) error) error {
+	p := &txPager{lcd: lcd, events: events}
+	for {
+		t, txResponse, err := p.peek(ctx)
+		if err != nil || t == nil {
+			return err
+		}
+		p.next()
+		if err := fn(t, txResponse); err != nil {
+			return err
+		}
+	}
+}
+
+// walkActionTxs call fn on every tx matching events with a message of any of actions in commit order,
+// the searches of the actions are merged by height and by the index of the txs in their block,
+// a tx with messages of several actions is walked once
+func (lcd *LCDClient) walkActionTxs(ctx context.Context, actions []string, events []string, fn func(tx *txtypes.Tx, txResponse *sdk.TxResponse) error) error {
+	pagers := make([]*txPager, len(actions))
+	for i, action := range actions {
+		pagers[i] = &txPager{lcd: lcd, events: append([]string{fmt.Sprintf("message.action='%s'", action)}, events...)}
+	}
+
+	// indexes the tx indexes of the block at indexHeight, seen the txs walked at seenHeight
+	var indexes map[string]int
+	indexHeight, seenHeight := int64(-1), int64(-1)
+	seen := map[string]bool{}
+	for {
+		var next *txPager
+		var nextTx *txtypes.Tx
+		var nextResponse *sdk.TxResponse
+		for _, p := range pagers {
+			t, txResponse, err := p.peek(ctx)
+			if err != nil {
+				return err
+			}
+			switch {
+			case t == nil:
+				continue
+			case next == nil || txResponse.Height < nextResponse.Height:
+			case txResponse.Height > nextResponse.Height || txResponse.TxHash == nextResponse.TxHash:
+				continue
+			default:
+				// txs of the same height found by different searches, order them as in the block
+				if indexHeight != txResponse.Height {
+					if indexes, err = lcd.blockTxIndexes(ctx, txResponse.Height); err != nil {
+						return err
+					}
+					indexHeight = txResponse.Height
+				}
+				if indexes[txResponse.TxHash] > indexes[nextResponse.TxHash] {
+					continue
+				}
+			}
+			next, nextTx, nextResponse = p, t, txResponse
+		}
+		if next == nil {
+			return nil
+		}
+		next.next()
+
+		if nextResponse.Height != seenHeight {
+			seenHeight, seen = nextResponse.Height, map[string]bool{}
+		}
+		if seen[nextResponse.TxHash] {
+			continue
+		}
+		seen[nextResponse.TxHash] = true
+		if err := fn(nextTx, nextResponse); err != nil {
+			return err
+		}
+	}
+}
+
+// blockTxIndexes returns the index of every tx of the block at height by its hash
+func (lcd *LCDClient) blockTxIndexes(ctx context.Context, height int64) (map[string]int, error) {
+	var response tmservice.GetBlockByHeightResponse
+	if err := lcd.queryJSON(ctx, fmt.Sprintf("/cosmos/base/tendermint/v1beta1/blocks/%d", height), &response); err != nil {
+		return nil, err
+	}
+	if response.Block == nil {
+		return nil, fmt.Errorf("node %s reports no block at height %d", lcd.URL, height)
+	}
+	indexes := make(map[string]int, len(response.Block.Data.Txs))
+	for i, t := range response.Block.Data.Txs {
+		indexes[txHash(t)] = i
+	}
+	return indexes, nil
+}
+
+// txHash returns the upper case hex hash of the raw tx, the form of TxResponse.TxHash
+func txHash(txBytes []byte) string {
+	hash := sha256.Sum256(txBytes)
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// txSearchServer serves the tx search and the blocks of the committed txs, the search filters
+// by the message.action and tx.height>= conditions and rejects a page past the last one as the node does
+type txSearchServer struct {
+	t   *testing.T
+	lcd *LCDClient
+
+	mu        sync.Mutex
+	txs       []*txtypes.Tx
+	raw       [][]byte
+	responses []*sdk.TxResponse
+	searches  int
+}
+
+// commitMsgs commit a tx of msgs at height after the txs committed before, returns the hash of the tx
+func (s *txSearchServer) commitMsgs(height int64, msgs ...sdk.Msg) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var anys []*codectypes.Any
+	for _, m := range msgs {
+		a, err := codectypes.NewAnyWithValue(m)
+		require.NoError(s.t, err)
+		anys = append(anys, a)
+	}
+	txMsgData := testTxMsgData(s.t, msgs...)
+
+	// the memo keeps the bytes and so the hash of every tx unique
+	t := &txtypes.Tx{Body: &txtypes.TxBody{Messages: anys, Memo: strconv.Itoa(len(s.txs))}, AuthInfo: &txtypes.AuthInfo{}}
+	raw, err := proto.Marshal(t)
+	require.NoError(s.t, err)
+	hash := txHash(raw)
+	s.txs = append(s.txs, t)
+	s.raw = append(s.raw, raw)
+	s.responses = append(s.responses, &sdk.TxResponse{
+		Height:    height,
+		TxHash:    hash,
+		Data:      strings.ToUpper(hex.EncodeToString(txMsgData)),
+		Timestamp: "2023-08-26T08:01:43Z",
+	})
+	return hash
+}
+
+// commitSQL commit a tx executing the sqls at height, returns the hash of the tx
+func (s *txSearchServer) commitSQL(height int64, sqls ...string) string {
+	return s.commitMsgs(height, testSQLMsgs(sqls...)...)
+}
+
+// testSQLMsgs returns the SQLExecRequest of testSigner executing the sqls
+func testSQLMsgs(sqls ...string) []sdk.Msg {
+	var msgs []sdk.Msg
+	for i, sql := range sqls {
+		msgs = append(msgs, &glittertypes.SQLExecRequest{
+			Uid:       testSigner,
+			Sql:       sql,
+			Arguments: []*glittertypes.Argument{{Type: glittertypes.Argument_INT, Value: strconv.Itoa(i)}},
+		})
+	}
+	return msgs
+}
+
+// testTxMsgData returns the encoded TxMsgData of a committed tx of msgs, the SQLExecRequest
+// respond with an empty SQLExecResponse, directly or in the results of a MsgExec
+func testTxMsgData(t *testing.T, msgs ...sdk.Msg) []byte {
+	responseData := func(m sdk.Msg) []byte {
+		if _, ok := m.(*glittertypes.SQLExecRequest); !ok {
+			return nil
+		}
+		bz, err := proto.Marshal(&glittertypes.SQLExecResponse{})
+		require.NoError(t, err)
+		return bz
+	}
+	var data []*sdk.MsgData
+	for _, m := range msgs {
+		msgData := &sdk.MsgData{MsgType: sdk.MsgTypeURL(m), Data: responseData(m)}
+		if exec, ok := m.(*authz.MsgExec); ok {
+			authorized, err := exec.GetMessages()
+			require.NoError(t, err)
+			var execResponse authz.MsgExecResponse
+			for _, a := range authorized {
+				execResponse.Results = append(execResponse.Results, responseData(a))
+			}
+			msgData.Data, err = execResponse.Marshal()
+			require.NoError(t, err)
+		}
+		data = append(data, msgData)
+	}
+	bz, err := proto.Marshal(&sdk.TxMsgData{Data: data})
+	require.NoError(t, err)
+	return bz
+}
+
+func (s *txSearchServer) matches(i int, events []string) bool {
+	for _, e := range events {
+		switch {
+		case strings.HasPrefix(e, "tx.height>="):
+			from, _ := strconv.ParseInt(strings.TrimPrefix(e, "tx.height>="), 10, 64)
+			if s.responses[i].Height < from {
+				return false
+			}
+		case strings.HasPrefix(e, "message.action="):
+			action := strings.Trim(strings.TrimPrefix(e, "message.action="), "'")
+			found := false
+			for _, m := range s.txs[i].Body.Messages {
+				found = found || m.TypeUrl == action
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *txSearchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/cosmos/base/tendermint/v1beta1/blocks/") {
+		height, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/cosmos/base/tendermint/v1beta1/blocks/"), 10, 64)
+		block := &tmproto.Block{}
+		for i, txResponse := range s.responses {
+			if txResponse.Height == height {
+				block.Data.Txs = append(block.Data.Txs, s.raw[i])
+			}
+		}
+		bz, err := s.lcd.GetMarshaler().MarshalJSON(&tmservice.GetBlockByHeightResponse{Block: block})
+		require.NoError(s.t, err)
+		w.Write(bz)
+		return
+	}
+	if r.URL.Path != "/cosmos/tx/v1beta1/txs" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.searches++
+	offset, _ := strconv.Atoi(r.URL.Query().Get("pagination.offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("pagination.limit"))
+
+	response := &txtypes.GetTxsEventResponse{}
+	for i := range s.responses {
+		if s.matches(i, r.URL.Query()["events"]) {
+			response.Txs = append(response.Txs, s.txs[i])
+			response.TxResponses = append(response.TxResponses, s.responses[i])
+		}
+	}
+	total := len(response.Txs)
+	if total > 0 && offset >= total {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"code":2,"message":"page should be within [1, %d] range, given %d"}`, (total+limit-1)/limit, offset/limit+1)
+		return
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	response.Txs, response.TxResponses = response.Txs[offset:end], response.TxResponses[offset:end]
+	response.Pagination = &query.PageResponse{Total: uint64(total)}
+
+	bz, err := s.lcd.GetMarshaler().MarshalJSON(response)
+	require.NoError(s.t, err)
+	w.Write(bz)
+}
+
+// testAddress returns a distinct address for every n
+func testAddress(n byte) msg.AccAddress {
+	a := msg.AccAddress(make([]byte, 20))
+	a[0] = n
+	return a
+}
+
+func Test_WalkTxs(t *testing.T) {
+	search := &txSearchServer{t: t, lcd: New("glitter_12000-2", nil)}
+	for i := 0; i < defaultSearchPageSize; i++ {
+		search.commitSQL(int64(i+1), fmt.Sprintf("insert into db.t values (%d)", i))
+	}
+	srv := httptest.NewServer(search)
+	defer srv.Close()
+
+	// a full last page stops at the total instead of asking for the page past it
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	var heights []int64
+	err := lcd.walkTxs(context.Background(), []string{fmt.Sprintf("message.action='%s'", SQLExecMsgTypeURL)}, func(_ *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		heights = append(heights, txResponse.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, heights, defaultSearchPageSize)
+	assert.Equal(t, int64(defaultSearchPageSize), heights[len(heights)-1])
+	assert.Equal(t, 1, search.searches)
+
+	search.commitSQL(defaultSearchPageSize+1, "insert into db.t values (100)")
+	heights = nil
+	err = lcd.walkTxs(context.Background(), nil, func(_ *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		heights = append(heights, txResponse.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, heights, defaultSearchPageSize+1)
+}
+
+func Test_WalkActionTxs(t *testing.T) {
+	search := &txSearchServer{t: t, lcd: New("glitter_12000-2", nil)}
+	onBehalfOf := func(sqls ...string) sdk.Msg {
+		exec := authz.NewMsgExec(testAddress(1), testSQLMsgs(sqls...))
+		return &exec
+	}
+	// the txs of a block found by the two searches are walked in block order
+	direct3 := search.commitSQL(3, "insert into db.t values (1)")
+	exec3 := search.commitMsgs(3, onBehalfOf("insert into db.t values (2)"))
+	both3 := search.commitMsgs(3, append(testSQLMsgs("insert into db.t values (3)"), onBehalfOf("insert into db.t values (4)"))...)
+	search.commitMsgs(4, msg.NewMsgSend(testAddress(1), testAddress(2), msg.NewCoins(msg.NewInt64Coin("agli", 1))))
+	exec5 := search.commitMsgs(5, onBehalfOf("insert into db.t values (5)"))
+	direct6 := search.commitSQL(6, "insert into db.t values (6)")
+	srv := httptest.NewServer(search)
+	defer srv.Close()
+
+	lcd := New("glitter_12000-2", nil, WithChainEndpoint(srv.URL))
+	var hashes []string
+	err := lcd.walkActionTxs(context.Background(), sqlExecActions, []string{"tx.height>=3"}, func(_ *txtypes.Tx, txResponse *sdk.TxResponse) error {
+		hashes = append(hashes, txResponse.TxHash)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{direct3, exec3, both3, exec5, direct6}, hashes)
+}
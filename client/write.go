@@ -72,6 +72,26 @@ func (lcd *LCDClient) Delete(ctx context.Context, db, table string, where map[st
 	return lcd.SQLExec(ctx, sql, args)
 }
 
+// DeleteResult Delete rows like Delete and wait until the tx is committed
+// Args:
+//   - options: Tx options, such as the fee, gas limit, memo and fee granter
+//   - db: The database name
+//   - table: The table name
+//   - where: Condition to match rows to delete
+//   - order_by: Column to order deletion
+//   - asc: Sort order ascending if True
+//   - limit: Max number of rows to delete
+//
+// Returns:
+// The response of the committed DELETE statement and its decoded result
+func (lcd *LCDClient) DeleteResult(ctx context.Context, options CreateTxOptions, db, table string, where map[string]interface{}, orderBy string, asc bool, limit int) (*sdk.TxResponse, *ExecResult, error) {
+	sql, args, err := utils.BuildDeleteStatement(utils.FullTableName(db, table), where, orderBy, asc, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcd.SQLExecResult(ctx, options, sql, args)
+}
+
 // Insert a new row into the specified table with the provided column-value pairs
 // Args:
 //   - db: The database name
@@ -88,6 +108,23 @@ func (lcd *LCDClient) Insert(ctx context.Context, db, table string, columns map[
 	return lcd.SQLExec(ctx, insertSql, args)
 }
 
+// InsertResult Insert a row like Insert and wait until the tx is committed
+// Args:
+//   - options: Tx options, such as the fee, gas limit, memo and fee granter
+//   - db: The database name
+//   - table: The table name
+//   - columns: A dictionary of column names and values to insert
+//
+// Returns:
+// The response of the committed INSERT statement and its decoded result
+func (lcd *LCDClient) InsertResult(ctx context.Context, options CreateTxOptions, db, table string, columns map[string]interface{}) (*sdk.TxResponse, *ExecResult, error) {
+	insertSql, args, err := utils.BuildInsertStatement(utils.FullTableName(db, table), columns)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcd.SQLExecResult(ctx, options, insertSql, args)
+}
+
 // BatchInsert insert multiple rows into the specified table using the provided column names and row values
 // Args:
 //   - db: The database name
@@ -104,6 +141,24 @@ func (lcd *LCDClient) BatchInsert(ctx context.Context, db, table string, columns
 	return lcd.SQLExec(ctx, batchInsertSql, args)
 }
 
+// BatchInsertResult Insert rows like BatchInsert and wait until the tx is committed
+// Args:
+//   - options: Tx options, such as the fee, gas limit, memo and fee granter
+//   - db: The database name
+//   - table: The table name
+//   - columns: The column names of the rows
+//   - rowValues: A list of rows to insert, each row holds the values of columns in order
+//
+// Returns:
+// The response of the committed batch INSERT statement and its decoded result
+func (lcd *LCDClient) BatchInsertResult(ctx context.Context, options CreateTxOptions, db, table string, columns []string, rowValues [][]interface{}) (*sdk.TxResponse, *ExecResult, error) {
+	batchInsertSql, args, err := utils.BuildBatchInsertStatement(utils.FullTableName(db, table), columns, rowValues)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcd.SQLExecResult(ctx, options, batchInsertSql, args)
+}
+
 // Update rows in the specified table with the provided column-value pairs based on the specified conditions
 // Args:
 //   - db: The database name
@@ -120,3 +175,21 @@ func (lcd *LCDClient) Update(ctx context.Context, db, table string, columns map[
 	}
 	return lcd.SQLExec(ctx, updateSql, args)
 }
+
+// UpdateResult Update rows like Update and wait until the tx is committed
+// Args:
+//   - options: Tx options, such as the fee, gas limit, memo and fee granter
+//   - db: The database name
+//   - table: The table name
+//   - columnsValue: A dictionary of column names and updated values
+//   - where: A dictionary of column names and values to match
+//
+// Returns:
+// The response of the committed UPDATE statement and its decoded result
+func (lcd *LCDClient) UpdateResult(ctx context.Context, options CreateTxOptions, db, table string, columns map[string]interface{}, where map[string]interface{}) (*sdk.TxResponse, *ExecResult, error) {
+	updateSql, args, err := utils.BuildUpdateStatement(utils.FullTableName(db, table), columns, where)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lcd.SQLExecResult(ctx, options, updateSql, args)
+}
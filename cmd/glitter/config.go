@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	"github.com/spf13/cobra"
+)
+
+// Settings connection settings of the profile of the config file, see client.Config:
+//
+//	profile: ops
+//	profiles:
+//	  ops:
+//	    network: mainnet
+//	    mnemonic: ...
+type Settings struct {
+	client.Profile
+	// Key name of the stored key signing txs, the profile mnemonic signs txs when it is empty
+	Key string
+	// KeyringBackend keyring backend of the stored keys, os or the passphrase encrypted file
+	KeyringBackend string
+}
+
+// glitterHome returns the directory of the config file and the stored keys
+func glitterHome() (string, error) {
+	if home := os.Getenv("GLITTER_HOME"); len(home) > 0 {
+		return home, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".glitter"), nil
+}
+
+// resolveSettings resolve the profile of the config file, overridden by the GLITTER_* env
+// and then by the set flags
+func resolveSettings(cmd *cobra.Command) (Settings, error) {
+	path, err := configPath()
+	if err != nil {
+		return Settings{}, err
+	}
+	cfg, err := client.LoadConfig(path)
+	if err != nil {
+		return Settings{}, err
+	}
+	profile, err := cfg.Profile(flags.profile)
+	if err != nil {
+		if len(path) > 0 {
+			err = fmt.Errorf("%w in %s", err, path)
+		}
+		return Settings{}, err
+	}
+
+	s := Settings{
+		Profile:        *profile,
+		Key:            os.Getenv("GLITTER_KEY"),
+		KeyringBackend: utils.FirstNonEmpty(os.Getenv("GLITTER_KEYRING_BACKEND"), keyring.BackendOS),
+	}
+	fs := cmd.Flags()
+	if fs.Changed("endpoint") {
+		s.REST = flags.endpoint
+	}
+	if fs.Changed("chain-id") {
+		s.ChainID = flags.chainID
+	}
+	if fs.Changed("key") {
+		s.Key = flags.key
+	}
+	if fs.Changed("keyring-backend") {
+		s.KeyringBackend = flags.keyringBackend
+	}
+	if fs.Changed("hd-index") {
+		s.HDIndex = flags.hdIndex
+	}
+	return s, nil
+}
+
+// configPath returns --config, else GLITTER_CONFIG, else the config.yaml or config.toml of glitterHome
+// if exists, else empty for the built-in networks only
+func configPath() (string, error) {
+	if path := utils.FirstNonEmpty(flags.configFile, os.Getenv("GLITTER_CONFIG")); len(path) > 0 {
+		return path, nil
+	}
+	home, err := glitterHome()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range []string{"config.yaml", "config.toml"} {
+		path := filepath.Join(home, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// privKey returns the key signing txs
+func (s Settings) privKey() (key.PrivKey, error) {
+	if len(s.Key) > 0 {
+		kr, err := openKeyring(s.KeyringBackend, os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return loadPrivKey(kr, s.Key)
+	}
+	if len(s.Mnemonic) == 0 {
+		return nil, fmt.Errorf("no signing key, set --key, GLITTER_KEY, GLITTER_MNEMONIC or the mnemonic of the profile")
+	}
+	return s.PrivKey()
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/glitternetwork/glitter-sdk-go/exporter"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		sql, db, table, output, format, delimiter string
+		noHeader                                  bool
+		config                                    exporter.Config
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export query results or a whole table to CSV, JSON Lines or Parquet",
+		Example: `  glitter export --db library --table ebook -o ebook.parquet
+  glitter export --sql "select _id, title from library.ebook where query_string('title:dune')" --format jsonl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(sql) > 0) == (len(db) > 0 || len(table) > 0) {
+				return fmt.Errorf("either --sql or --db and --table are required")
+			}
+			if len(sql) == 0 && (len(db) == 0 || len(table) == 0) {
+				return fmt.Errorf("both --db and --table are required")
+			}
+
+			config.Format = exporter.Format(format)
+			if len(format) == 0 {
+				config.Format = exporter.FormatCSV
+				if len(output) > 0 {
+					f, err := exporter.FormatOf(output)
+					if err != nil {
+						return err
+					}
+					config.Format = f
+				}
+			}
+			r, size := utf8.DecodeRuneInString(delimiter)
+			if size != len(delimiter) {
+				return fmt.Errorf("delimiter must be a single character")
+			}
+			config.Comma = r
+			config.NoHeader = noHeader
+			if len(output) > 0 {
+				config.OnProgress = func(stats exporter.Stats) {
+					fmt.Fprintf(os.Stderr, "\rrows=%d queries=%d %.1f rows/s", stats.Rows, stats.Queries, stats.RowsPerSecond())
+				}
+			}
+
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			e, err := exporter.New(lcd, config)
+			if err != nil {
+				return err
+			}
+			var w io.Writer = os.Stdout
+			if len(output) > 0 {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			var stats exporter.Stats
+			if len(sql) > 0 {
+				stats, err = e.ExportQuery(cmd.Context(), w, sql)
+			} else {
+				stats, err = e.ExportTable(cmd.Context(), w, db, table)
+			}
+			if len(output) > 0 {
+				fmt.Fprintf(os.Stderr, "\nexported %d rows in %s (%.1f rows/s)\n", stats.Rows, stats.Elapsed.Round(1e6), stats.RowsPerSecond())
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&sql, "sql", "", "SELECT statement to export")
+	cmd.Flags().StringVar(&db, "db", "", "database of the table to export")
+	cmd.Flags().StringVar(&table, "table", "", "table to export")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file, default to stdout")
+	cmd.Flags().StringVar(&format, "format", "", "output format, csv, jsonl or parquet, default to the output file extension or csv")
+	cmd.Flags().StringVar(&delimiter, "delimiter", ",", "CSV field delimiter")
+	cmd.Flags().BoolVar(&noHeader, "no-header", false, "do not write the CSV header line")
+	cmd.Flags().IntVar(&config.PageSize, "page-size", exporter.DefaultPageSize, "rows queried at a time when exporting a table")
+	cmd.Flags().IntVar(&config.RowGroupRows, "row-group-rows", exporter.DefaultRowGroupRows, "rows of a Parquet row group")
+	return cmd
+}
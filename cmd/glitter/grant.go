@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+func newGrantCmd() *cobra.Command {
+	var (
+		output, db, table, to string
+		revoke                bool
+	)
+	cmd := &cobra.Command{
+		Use:   "grant <reader|writer|admin>",
+		Short: "Grant or revoke a SQL role on a database or table",
+		Example: `  glitter grant reader --db library --table ebook --to glitter1...
+  glitter grant writer --db library --to glitter1... --revoke`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role := args[0]
+			if err := client.Role(role).Validate(); err != nil {
+				return fmt.Errorf("unknown role %q, expected reader, writer or admin", role)
+			}
+			lcd, err := newClient()
+			if err != nil {
+				return err
+			}
+			var txResponse *sdk.TxResponse
+			if revoke {
+				txResponse, err = lcd.SQLRevoke(cmd.Context(), db, table, to, role)
+			} else {
+				txResponse, err = lcd.SQLGrant(cmd.Context(), db, table, to, role)
+			}
+			if err != nil {
+				return err
+			}
+			return printTxResponse(cmd, output, txResponse)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	cmd.Flags().StringVar(&db, "db", "", "database of the role")
+	cmd.Flags().StringVar(&table, "table", "", "table of the role, the role applies to the whole database if empty")
+	cmd.Flags().StringVar(&to, "to", "", "address receiving the role, glitter1... or 0x...")
+	cmd.Flags().BoolVar(&revoke, "revoke", false, "revoke the role instead of granting it")
+	_ = cmd.MarkFlagRequired("db")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
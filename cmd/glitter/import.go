@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/importer"
+	"github.com/spf13/cobra"
+)
+
+func newImportCmd() *cobra.Command {
+	var (
+		db, table, format, delimiter, rejects, checkpoint string
+		columns, mapping                                  []string
+		noHeader                                          bool
+		load                                              client.BulkLoadConfig
+	)
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a CSV or JSON Lines file into a table",
+		Example: `  glitter import books.csv --db library --table ebook --map md5=_id --rejects books.rejects.csv
+  glitter import books.jsonl --db library --table ebook --checkpoint books.checkpoint`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			config := importer.Config{
+				Format:     importer.Format(format),
+				NoHeader:   noHeader,
+				Columns:    columns,
+				Mapping:    map[string]string{},
+				RejectFile: rejects,
+				Load:       load,
+			}
+			if len(format) == 0 {
+				f, err := importer.FormatOf(path)
+				if err != nil {
+					return err
+				}
+				config.Format = f
+			}
+			if len(delimiter) > 0 {
+				r, size := utf8.DecodeRuneInString(delimiter)
+				if size != len(delimiter) {
+					return fmt.Errorf("delimiter must be a single character")
+				}
+				config.Comma = r
+			}
+			for _, m := range mapping {
+				i := strings.IndexByte(m, '=')
+				if i < 0 {
+					return fmt.Errorf("invalid mapping %q, expected field=column", m)
+				}
+				config.Mapping[m[:i]] = m[i+1:]
+			}
+			config.Load.CheckpointFile = checkpoint
+			config.Load.OnProgress = func(stats client.BulkLoadStats) {
+				fmt.Fprintf(os.Stderr, "\rrows=%d chunks=%d retries=%d %.1f rows/s", stats.Rows, stats.Chunks, stats.Retries, stats.RowsPerSecond())
+			}
+
+			lcd, err := newClient()
+			if err != nil {
+				return err
+			}
+			im, err := importer.New(cmd.Context(), lcd, db, table, config)
+			if err != nil {
+				return err
+			}
+			stats, err := im.ImportFile(cmd.Context(), path)
+			fmt.Fprintf(os.Stderr, "\nimported %d rows, skipped %d already imported, rejected %d in %s (%.1f rows/s)\n",
+				stats.Rows, stats.SkippedRows, stats.Rejected, stats.Elapsed.Round(1e6), stats.RowsPerSecond())
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&db, "db", "", "database name")
+	cmd.Flags().StringVar(&table, "table", "", "table name")
+	cmd.Flags().StringVar(&format, "format", "", "file format, csv or jsonl, default to the file extension")
+	cmd.Flags().StringVar(&delimiter, "delimiter", ",", "CSV field delimiter")
+	cmd.Flags().BoolVar(&noHeader, "no-header", false, "the CSV has no header line, --columns names its fields")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "source fields to import, default to the CSV header or the keys of the first JSON record")
+	cmd.Flags().StringSliceVar(&mapping, "map", nil, "rename a source field to a table column as field=column, field= skips the field")
+	cmd.Flags().StringVar(&rejects, "rejects", "", "file receiving the records failing validation")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "", "checkpoint file to resume an interrupted import")
+	cmd.Flags().IntVar(&load.MaxChunkRows, "chunk-rows", client.DefaultBulkChunkRows, "max rows of an insert tx")
+	cmd.Flags().IntVar(&load.MaxChunkBytes, "chunk-bytes", client.DefaultBulkChunkBytes, "max bytes of the rows of an insert tx")
+	cmd.Flags().IntVar(&load.Concurrency, "concurrency", client.DefaultBulkConcurrency, "insert txs waiting for commit at the same time")
+	cmd.Flags().IntVar(&load.MaxRetries, "retries", client.DefaultBulkMaxRetries, "retries of a failed insert tx")
+	_ = cmd.MarkFlagRequired("db")
+	_ = cmd.MarkFlagRequired("table")
+	return cmd
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+	ethermintcodec "github.com/evmos/ethermint/crypto/codec"
+	ethhd "github.com/evmos/ethermint/crypto/hd"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+	"github.com/spf13/cobra"
+)
+
+// keyringAppName name of the keyring service, the os backend stores the keys under it
+const keyringAppName = "glitter"
+
+var keyNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var registerKeyringCodec sync.Once
+
+// openKeyring open the keyring of the backend in glitterHome, the file backend encrypts the keys
+// with a passphrase read from input, the os backend stores them in the keychain of the os
+func openKeyring(backend string, input io.Reader) (keyring.Keyring, error) {
+	home, err := glitterHome()
+	if err != nil {
+		return nil, err
+	}
+	// the keyring encodes the keys with the legacy amino codec, which must know the eth_secp256k1 keys
+	registerKeyringCodec.Do(func() { ethermintcodec.RegisterCrypto(codec.NewLegacyAmino()) })
+	return keyring.New(keyringAppName, backend, home, input, ethhd.EthSecp256k1Option())
+}
+
+// addKey store the key derived from mnemonic at the hd account and index as name
+func addKey(kr keyring.Keyring, name string, mnemonic string, account, index uint32) (*key.HDAccount, error) {
+	if !keyNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid key name %q", name)
+	}
+	if _, err := kr.Key(name); err == nil {
+		return nil, fmt.Errorf("key %s already exists", name)
+	}
+	wallet, err := key.NewHDWallet(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := wallet.Derive(account, index)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := kr.NewAccount(name, mnemonic, "", acc.HDPath, ethhd.EthSecp256k1); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// loadPrivKey returns the private key stored as name
+func loadPrivKey(kr keyring.Keyring, name string) (key.PrivKey, error) {
+	privKeyHex, err := keyring.NewUnsafe(kr).UnsafeExportPrivKeyHex(name)
+	if sdkerrors.IsOf(err, sdkerrors.ErrKeyNotFound) {
+		if path, ok := legacyKeyFile(name); ok {
+			return nil, fmt.Errorf("key %s is stored unencrypted in %s, import its mnemonic with glitter keys import %s and delete the file", name, path, name)
+		}
+		return nil, fmt.Errorf("key %s not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	bz, err := hex.DecodeString(privKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivKeyGen(bz)
+}
+
+// legacyKeyFile returns the plaintext key file of ~/.glitter/keys written by the former versions
+func legacyKeyFile(name string) (string, bool) {
+	home, err := glitterHome()
+	if err != nil || !keyNameRegexp.MatchString(name) {
+		return "", false
+	}
+	path := filepath.Join(home, "keys", name+".json")
+	_, err = os.Stat(path)
+	return path, err == nil
+}
+
+func newKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the keys stored in the keyring of --keyring-backend",
+	}
+	cmd.AddCommand(newKeysCreateCmd(), newKeysImportCmd(), newKeysListCmd())
+	return cmd
+}
+
+func newKeysCreateCmd() *cobra.Command {
+	var account, index uint32
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a key from a new mnemonic",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kr, err := openKeyring(settings.KeyringBackend, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			mnemonic, err := key.CreateMnemonic()
+			if err != nil {
+				return err
+			}
+			acc, err := addKey(kr, args[0], mnemonic, account, index)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "address: %s\nevm address: %s\nhd path: %s\n\n", acc.Bech32Address(), acc.EvmAddress(), acc.HDPath)
+			fmt.Fprintf(cmd.OutOrStdout(), "Write down the mnemonic, it is the only way to recover the key:\n%s\n", mnemonic)
+			return nil
+		},
+	}
+	cmd.Flags().Uint32Var(&account, "account", 0, "hd account of the key")
+	cmd.Flags().Uint32Var(&index, "index", 0, "hd address index of the key")
+	return cmd
+}
+
+func newKeysImportCmd() *cobra.Command {
+	var account, index uint32
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Import a key from a mnemonic read from stdin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the file backend reads its passphrase from the same reader as the mnemonic
+			input := bufio.NewReader(cmd.InOrStdin())
+			fmt.Fprint(os.Stderr, "mnemonic: ")
+			line, err := input.ReadString('\n')
+			if err != nil && len(line) == 0 {
+				return fmt.Errorf("failed to read mnemonic: %w", err)
+			}
+			kr, err := openKeyring(settings.KeyringBackend, input)
+			if err != nil {
+				return err
+			}
+			acc, err := addKey(kr, args[0], strings.Join(strings.Fields(line), " "), account, index)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "address: %s\nevm address: %s\nhd path: %s\n", acc.Bech32Address(), acc.EvmAddress(), acc.HDPath)
+			return nil
+		},
+	}
+	cmd.Flags().Uint32Var(&account, "account", 0, "hd account of the key")
+	cmd.Flags().Uint32Var(&index, "index", 0, "hd address index of the key")
+	return cmd
+}
+
+func newKeysListCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the stored keys",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kr, err := openKeyring(settings.KeyringBackend, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			infos, err := kr.List()
+			if err != nil {
+				return err
+			}
+			sort.Slice(infos, func(i, j int) bool { return infos[i].GetName() < infos[j].GetName() })
+
+			rows := make([][]interface{}, 0, len(infos))
+			for _, info := range infos {
+				address := info.GetAddress()
+				rows = append(rows, []interface{}{info.GetName(), msg.GlitterBech32Codec.AccAddressString(address), common.BytesToAddress(address).Hex()})
+			}
+			return printRows(cmd.OutOrStdout(), output, []string{"name", "address", "evm_address"}, rows)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	return cmd
+}
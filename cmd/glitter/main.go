@@ -0,0 +1,95 @@
+// Command glitter is the command line client of glitter chain
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+// globalFlags flags shared by all commands
+type globalFlags struct {
+	configFile     string
+	profile        string
+	endpoint       string
+	chainID        string
+	key            string
+	keyringBackend string
+	hdIndex        uint32
+}
+
+var flags globalFlags
+
+// settings connection settings resolved from the flags, GLITTER_* env and the profile
+var settings Settings
+
+func main() {
+	root := &cobra.Command{
+		Use:           "glitter",
+		Short:         "Command line client of glitter chain",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			s, err := resolveSettings(cmd)
+			if err != nil {
+				return err
+			}
+			settings = s
+			return nil
+		},
+	}
+	pf := root.PersistentFlags()
+	pf.StringVar(&flags.configFile, "config", "", "YAML or TOML config file, default to $GLITTER_CONFIG or ~/.glitter/config.yaml")
+	pf.StringVar(&flags.profile, "profile", "", "profile of the config file or built-in network mainnet, testnet or local, default to $GLITTER_PROFILE or mainnet")
+	pf.StringVar(&flags.endpoint, "endpoint", "", "chain rest endpoint, default to $GLITTER_REST or the rest of the profile")
+	pf.StringVar(&flags.chainID, "chain-id", "", "chain id, default to $GLITTER_CHAIN_ID or the chain_id of the profile")
+	pf.StringVar(&flags.key, "key", "", "name of the stored key signing txs, $GLITTER_KEY, default to the key derived from the mnemonic of the profile")
+	pf.StringVar(&flags.keyringBackend, "keyring-backend", "", "keyring backend of the stored keys, os or file, default to $GLITTER_KEYRING_BACKEND or os")
+	pf.Uint32Var(&flags.hdIndex, "hd-index", 0, "address index of the key derived from the mnemonic of the profile, $GLITTER_HD_INDEX")
+
+	root.AddCommand(
+		newQueryCmd(),
+		newExecCmd(),
+		newTablesCmd(),
+		newDatabasesCmd(),
+		newShowCreateCmd(),
+		newGrantCmd(),
+		newKeysCmd(),
+		newTxCmd(),
+		newShellCmd(),
+		newImportCmd(),
+		newExportCmd(),
+	)
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// newClient create client signing with the configured key
+func newClient(options ...client.Option) (*client.LCDClient, error) {
+	privKey, err := settings.privKey()
+	if err != nil {
+		return nil, err
+	}
+	profile := settings.Profile
+	profile.Mnemonic = ""
+	// catch a wrong chain id before signing instead of as a signature verification failure
+	options = append([]client.Option{client.WithHandshake(client.NodeRequirements{})}, options...)
+	lcd, err := client.NewFromConfig(&profile, options...)
+	if err != nil {
+		return nil, err
+	}
+	lcd.PrivKey = privKey
+	return lcd, nil
+}
+
+// newQueryClient create client for the commands that only query the chain
+func newQueryClient() (*client.LCDClient, error) {
+	profile := settings.Profile
+	profile.Mnemonic = ""
+	return client.NewFromConfig(&profile)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseArguments(t *testing.T) {
+	assert.Equal(t, []*glittertypes.Argument{
+		{Type: glittertypes.Argument_INT, Value: "-5"},
+		{Type: glittertypes.Argument_STRING, Value: "title:dune"},
+		{Type: glittertypes.Argument_BYTES, Value: "aGk="},
+		{Type: glittertypes.Argument_STRING, Value: "plain"},
+	}, parseArguments([]string{"int:-5", "title:dune", "BYTES:aGk=", "plain"}))
+}
+
+func Test_PrintRows(t *testing.T) {
+	columns := []string{"_id", "title", "cover"}
+	rows := [][]interface{}{{int64(1), "Dune", []byte("hi")}, {int64(2), "Emma\nJane", nil}}
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, printRows(out, outputTable, columns, rows))
+	assert.Equal(t, "_ID  TITLE       COVER\n1    Dune        aGk=\n2    Emma\\nJane  \n", out.String())
+
+	out.Reset()
+	assert.NoError(t, printRows(out, outputJSON, columns, rows))
+	assert.Equal(t, `[
+  {
+    "_id": 1,
+    "title": "Dune",
+    "cover": "aGk="
+  },
+  {
+    "_id": 2,
+    "title": "Emma\nJane",
+    "cover": null
+  }
+]
+`, out.String())
+
+	out.Reset()
+	assert.NoError(t, printRows(out, outputCSV, columns, rows))
+	assert.Equal(t, "_id,title,cover\n1,Dune,aGk=\n2,\"Emma\nJane\",\n", out.String())
+
+	assert.EqualError(t, printRows(out, "xml", columns, rows), `unknown output format "xml", expected table, json or csv`)
+}
+
+func Test_ResolveSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GLITTER_HOME", home)
+	for _, env := range []string{"GLITTER_CONFIG", "GLITTER_PROFILE", "GLITTER_REST", "GLITTER_ENDPOINT", "GLITTER_CHAIN_ID", "GLITTER_KEY", "GLITTER_KEYRING_BACKEND", "GLITTER_MNEMONIC", "GLITTER_HD_INDEX"} {
+		t.Setenv(env, "")
+	}
+
+	resolve := func(args ...string) (Settings, error) {
+		flags = globalFlags{}
+		cmd := &cobra.Command{}
+		cmd.Flags().StringVar(&flags.profile, "profile", "", "")
+		cmd.Flags().StringVar(&flags.endpoint, "endpoint", "", "")
+		cmd.Flags().StringVar(&flags.chainID, "chain-id", "", "")
+		cmd.Flags().StringVar(&flags.key, "key", "", "")
+		cmd.Flags().StringVar(&flags.keyringBackend, "keyring-backend", "", "")
+		cmd.Flags().Uint32Var(&flags.hdIndex, "hd-index", 0, "")
+		assert.NoError(t, cmd.ParseFlags(args))
+		return resolveSettings(cmd)
+	}
+
+	s, err := resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, client.NetworkMainnet, s.Name)
+	assert.Equal(t, client.DefaultChainEndpoint, s.REST)
+	assert.Equal(t, keyring.BackendOS, s.KeyringBackend)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(`profile: dev
+profiles:
+  dev:
+    network: local
+    rest: http://dev:1317
+  ops:
+    rest: http://ops:1317
+    chain_id: glitter_12001-1
+    network: testnet
+`), 0600))
+
+	s, err = resolve()
+	assert.NoError(t, err)
+	assert.Equal(t, "http://dev:1317", s.REST)
+	assert.Empty(t, s.ChainID)
+
+	s, err = resolve("--profile", "ops", "--key", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://ops:1317", s.REST)
+	assert.Equal(t, "glitter_12001-1", s.ChainID)
+	assert.Equal(t, "other", s.Key)
+
+	t.Setenv("GLITTER_ENDPOINT", "http://env:1317")
+	t.Setenv("GLITTER_KEY", "ops")
+	t.Setenv("GLITTER_HD_INDEX", "3")
+	s, err = resolve("--profile", "ops")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://env:1317", s.REST)
+	assert.Equal(t, "ops", s.Key)
+	assert.Equal(t, uint32(3), s.HDIndex)
+
+	t.Setenv("GLITTER_KEYRING_BACKEND", "file")
+	s, err = resolve("--profile", "ops", "--endpoint", "http://flag:1317", "--hd-index", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://flag:1317", s.REST)
+	assert.Equal(t, uint32(1), s.HDIndex)
+	assert.Equal(t, keyring.BackendFile, s.KeyringBackend)
+
+	s, err = resolve("--keyring-backend", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, keyring.BackendTest, s.KeyringBackend)
+
+	_, err = resolve("--profile", "staging")
+	assert.EqualError(t, err, "profile staging not found in "+filepath.Join(home, "config.yaml"))
+}
+
+func Test_Keyring(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GLITTER_HOME", home)
+	kr, err := openKeyring(keyring.BackendTest, nil)
+	require.NoError(t, err)
+
+	mnemonic, err := key.CreateMnemonic()
+	require.NoError(t, err)
+	acc, err := addKey(kr, "ops", mnemonic, 0, 2)
+	require.NoError(t, err)
+	_, err = addKey(kr, "ops", mnemonic, 0, 0)
+	assert.EqualError(t, err, "key ops already exists")
+	_, err = addKey(kr, "../ops", mnemonic, 0, 0)
+	assert.Error(t, err)
+
+	// the signing key is the key derived at the hd path, not the one of index 0
+	privKey, err := loadPrivKey(kr, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, acc.PrivKey.Bytes(), privKey.Bytes())
+	info, err := kr.Key("ops")
+	require.NoError(t, err)
+	assert.Equal(t, acc.Address(), info.GetAddress())
+
+	_, err = loadPrivKey(kr, "dev")
+	assert.EqualError(t, err, "key dev not found")
+	// the plaintext key files of the former versions are not read
+	legacy := filepath.Join(home, "keys", "dev.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacy), 0700))
+	require.NoError(t, os.WriteFile(legacy, []byte(`{"name":"dev"}`), 0600))
+	_, err = loadPrivKey(kr, "dev")
+	assert.ErrorContains(t, err, "stored unencrypted in "+legacy)
+}
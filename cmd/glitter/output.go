@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// output formats of printRows
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", outputTable, "output format, table, json or csv")
+}
+
+// printRows print rows of values as an aligned table, a JSON array of objects keyed by column or CSV
+func printRows(w io.Writer, output string, columns []string, rows [][]interface{}) error {
+	switch output {
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, row := range rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				// keep a multiline value on its row
+				cells[i] = strings.NewReplacer("\n", `\n`, "\t", " ").Replace(formatValue(v))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	case outputJSON:
+		objects := make([]json.RawMessage, 0, len(rows))
+		for _, row := range rows {
+			var b strings.Builder
+			b.WriteByte('{')
+			for i, v := range row {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				name, _ := json.Marshal(columns[i])
+				value, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				b.Write(name)
+				b.WriteByte(':')
+				b.Write(value)
+			}
+			b.WriteByte('}')
+			objects = append(objects, json.RawMessage(b.String()))
+		}
+		bz, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(bz))
+		return err
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(columns)
+		for _, row := range rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				record[i] = formatValue(v)
+			}
+			cw.Write(record)
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json or csv", output)
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/exporter"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/spf13/cobra"
+)
+
+// argumentTypes type prefixes of --arg values
+var argumentTypes = map[string]glittertypes.Argument_ArgumentType{
+	"int":    glittertypes.Argument_INT,
+	"uint":   glittertypes.Argument_UINT,
+	"float":  glittertypes.Argument_FLOAT,
+	"bool":   glittertypes.Argument_BOOL,
+	"string": glittertypes.Argument_STRING,
+	"bytes":  glittertypes.Argument_BYTES,
+}
+
+// parseArguments parse --arg values as type:value, values without a known type prefix are strings
+func parseArguments(values []string) []*glittertypes.Argument {
+	args := make([]*glittertypes.Argument, 0, len(values))
+	for _, v := range values {
+		arg := &glittertypes.Argument{Type: glittertypes.Argument_STRING, Value: v}
+		if i := strings.IndexByte(v, ':'); i > 0 {
+			if t, ok := argumentTypes[strings.ToLower(v[:i])]; ok {
+				arg.Type, arg.Value = t, v[i+1:]
+			}
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+func addArgFlag(cmd *cobra.Command, args *[]string) {
+	cmd.Flags().StringArrayVarP(args, "arg", "a", nil, "argument of a ? placeholder as type:value, type is int, uint, float, bool, string or bytes (base64), default to string")
+}
+
+// printResultSets print every result set of a query, BYTES values are printed base64 encoded
+func printResultSets(cmd *cobra.Command, output string, results []*glittertypes.ResultSet) error {
+	for n, rs := range results {
+		if n > 0 && output == outputTable {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		columns := make([]string, len(rs.ColumnDefs))
+		for i, c := range rs.ColumnDefs {
+			columns[i] = c.ColumnName
+		}
+		rows := make([][]interface{}, 0, len(rs.Rows))
+		for _, row := range rs.Rows {
+			values := make([]interface{}, len(row.Columns))
+			for i, s := range row.Columns {
+				values[i] = s
+				if i < len(rs.ColumnDefs) {
+					if v, err := exporter.DecodeValue(rs.ColumnDefs[i].ColumnValueType, s); err == nil {
+						values[i] = v
+					}
+				}
+			}
+			rows = append(rows, values)
+		}
+		if err := printRows(cmd.OutOrStdout(), output, columns, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newQueryCmd() *cobra.Command {
+	var (
+		output string
+		args   []string
+	)
+	cmd := &cobra.Command{
+		Use:     "query <sql>",
+		Short:   "Run a SELECT statement",
+		Example: `  glitter query "select _id, title from library.ebook where query_string(?) limit 10" --arg "title:dune" -o json`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, posArgs []string) error {
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.Query(cmd.Context(), posArgs[0], parseArguments(args)...)
+			if err != nil {
+				return err
+			}
+			return printResultSets(cmd, output, res.Results)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	addArgFlag(cmd, &args)
+	return cmd
+}
+
+func newExecCmd() *cobra.Command {
+	var (
+		output string
+		args   []string
+		dryRun bool
+	)
+	cmd := &cobra.Command{
+		Use:     "exec <sql>",
+		Short:   "Execute a SQL statement in a tx and wait for its commit",
+		Example: `  glitter exec "update library.ebook set title = ? where _id = ?" --arg Dune --arg 42 --key ops`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, posArgs []string) error {
+			lcd, err := newClient(client.WithDryRun(dryRun))
+			if err != nil {
+				return err
+			}
+			txResponse, res, err := lcd.SQLExecResult(cmd.Context(), client.CreateTxOptions{}, posArgs[0], parseArguments(args))
+			if err != nil {
+				return err
+			}
+			return printRows(cmd.OutOrStdout(), output,
+				[]string{"tx_hash", "height", "gas_used"},
+				[][]interface{}{{res.TxHash, res.Height, txResponse.GasUsed}})
+		},
+	}
+	addOutputFlag(cmd, &output)
+	addArgFlag(cmd, &args)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the statement without broadcasting it")
+	return cmd
+}
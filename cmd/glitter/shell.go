@@ -0,0 +1,533 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/peterh/liner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shellPrompt             = "glitter> "
+	shellContinuationPrompt = "      -> "
+	shellHelp               = `Statements end with ';' and may span several lines.
+SELECT, SHOW, DESC and EXPLAIN statements are queried, other statements are executed in a tx.
+
+  \l             list databases
+  \dt [db]       list the tables of db, default to the current database
+  \d [db.]table  describe the columns of a table
+  \c db          set the current database
+  \timing        toggle printing the time of each statement
+  \x             toggle expanded output, one line per column
+  \?             show this help
+  \q             quit
+`
+)
+
+// queryStatements statement kinds sent to Query, others are executed with SQLExec
+var queryStatements = []string{"SELECT", "SHOW", "DESC", "DESCRIBE", "EXPLAIN", "WITH"}
+
+// confirmStatements statement kinds asking for confirmation before they are executed
+var confirmStatements = []string{"CREATE", "DROP", "ALTER", "TRUNCATE", "RENAME", "DELETE"}
+
+var sqlKeywords = []string{
+	"SELECT", "FROM", "WHERE", "AND", "OR", "NOT", "IN", "LIKE", "IS", "NULL", "ORDER", "BY", "ASC", "DESC",
+	"LIMIT", "GROUP", "HAVING", "AS", "INSERT", "INTO", "VALUES", "UPDATE", "SET", "DELETE", "CREATE",
+	"TABLE", "DATABASE", "DROP", "ALTER", "SHOW", "TABLES", "DATABASES", "QUERY_STRING", "COUNT",
+}
+
+func newShellCmd() *cobra.Command {
+	var db string
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Interactive SQL shell",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			sh := newShell(cmd.Context(), lcd, cmd.OutOrStdout())
+			sh.database = db
+			return sh.run()
+		},
+	}
+	cmd.Flags().StringVar(&db, "db", "", "current database")
+	return cmd
+}
+
+// shell state of an interactive session
+type shell struct {
+	ctx      context.Context
+	lcd      *client.LCDClient
+	signer   *client.LCDClient
+	out      io.Writer
+	line     *liner.State
+	catalog  *catalog
+	database string
+	timing   bool
+	expanded bool
+}
+
+func newShell(ctx context.Context, lcd *client.LCDClient, out io.Writer) *shell {
+	return &shell{
+		ctx:     ctx,
+		lcd:     lcd,
+		out:     out,
+		catalog: newCatalog(ctx, lcd),
+	}
+}
+
+func (sh *shell) run() error {
+	sh.line = liner.NewLiner()
+	defer sh.line.Close()
+	sh.line.SetCtrlCAborts(true)
+	sh.line.SetMultiLineMode(true)
+	sh.line.SetWordCompleter(func(line string, pos int) (string, []string, string) {
+		return sh.catalog.complete(line, pos, sh.database)
+	})
+
+	historyFile := ""
+	if home, err := glitterHome(); err == nil {
+		historyFile = filepath.Join(home, "history")
+		if f, err := os.Open(historyFile); err == nil {
+			sh.line.ReadHistory(f)
+			f.Close()
+		}
+	}
+	defer func() {
+		if len(historyFile) == 0 {
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(historyFile), 0700); err != nil {
+			return
+		}
+		if f, err := os.OpenFile(historyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600); err == nil {
+			sh.line.WriteHistory(f)
+			f.Close()
+		}
+	}()
+
+	fmt.Fprintf(sh.out, "Connected to %s (%s). Type \\? for help.\n", settings.REST, settings.ChainID)
+	var buf strings.Builder
+	for {
+		prompt := shellPrompt
+		if buf.Len() > 0 {
+			prompt = shellContinuationPrompt
+		}
+		input, err := sh.line.Prompt(prompt)
+		if errors.Is(err, liner.ErrPromptAborted) {
+			buf.Reset()
+			continue
+		}
+		if err == io.EOF {
+			fmt.Fprintln(sh.out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if buf.Len() == 0 && strings.HasPrefix(strings.TrimSpace(input), `\`) {
+			sh.line.AppendHistory(input)
+			if quit := sh.meta(strings.TrimSpace(input)); quit {
+				return nil
+			}
+			continue
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(input)
+
+		statements, rest := splitStatements(buf.String())
+		if len(statements) == 0 {
+			continue
+		}
+		sh.line.AppendHistory(strings.Join(strings.Fields(buf.String()), " "))
+		buf.Reset()
+		buf.WriteString(rest)
+		for _, stmt := range statements {
+			if err := sh.execute(stmt); err != nil {
+				fmt.Fprintln(sh.out, "error:", err)
+			}
+		}
+	}
+}
+
+// meta run a meta-command, returns true to quit the shell
+func (sh *shell) meta(input string) bool {
+	fields := strings.Fields(input)
+	var err error
+	switch fields[0] {
+	case `\q`:
+		return true
+	case `\?`, `\h`:
+		fmt.Fprint(sh.out, shellHelp)
+	case `\timing`:
+		sh.timing = !sh.timing
+		fmt.Fprintf(sh.out, "Timing is %s.\n", onOff(sh.timing))
+	case `\x`:
+		sh.expanded = !sh.expanded
+		fmt.Fprintf(sh.out, "Expanded display is %s.\n", onOff(sh.expanded))
+	case `\c`:
+		if len(fields) < 2 {
+			fmt.Fprintf(sh.out, "Current database is %q.\n", sh.database)
+			break
+		}
+		sh.database = fields[1]
+		fmt.Fprintf(sh.out, "Current database is %q.\n", sh.database)
+	case `\l`:
+		var databases []string
+		if databases, err = sh.catalog.databases(); err == nil {
+			err = sh.printTable([]string{"database"}, stringRows(databases))
+		}
+	case `\dt`:
+		db := sh.database
+		if len(fields) > 1 {
+			db = fields[1]
+		}
+		if len(db) == 0 {
+			err = fmt.Errorf("no database, use \\dt db or \\c db")
+			break
+		}
+		var tables []string
+		if tables, err = sh.catalog.tables(db); err == nil {
+			err = sh.printTable([]string{"table"}, stringRows(tables))
+		}
+	case `\d`:
+		if len(fields) < 2 {
+			err = fmt.Errorf("usage: \\d [db.]table")
+			break
+		}
+		err = sh.describe(fields[1])
+	default:
+		err = fmt.Errorf("unknown command %s, type \\? for help", fields[0])
+	}
+	if err != nil {
+		fmt.Fprintln(sh.out, "error:", err)
+	}
+	return false
+}
+
+// describe print the columns of [db.]table
+func (sh *shell) describe(name string) error {
+	db, table := sh.database, name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		db, table = name[:i], name[i+1:]
+	}
+	if len(db) == 0 {
+		return fmt.Errorf("no database, use \\d db.table or \\c db")
+	}
+	schema, err := sh.catalog.schema(db, table)
+	if err != nil {
+		return err
+	}
+	rows := make([][]interface{}, 0, len(schema.Columns))
+	for _, c := range schema.Columns {
+		typ := c.Type
+		if c.Length > 0 {
+			typ = fmt.Sprintf("%s(%d)", c.Type, c.Length)
+		}
+		if c.Unsigned {
+			typ += " UNSIGNED"
+		}
+		key := ""
+		if c.PrimaryKey {
+			key = "PRI"
+		}
+		rows = append(rows, []interface{}{c.Name, typ, !c.NotNull, key, c.Comment})
+	}
+	return sh.printTable([]string{"column", "type", "nullable", "key", "comment"}, rows)
+}
+
+// execute query or execute a statement, DDL and DELETE statements need confirmation
+func (sh *shell) execute(stmt string) error {
+	kind := strings.ToUpper(utils.StatementKind(stmt))
+	if utils.ContainsString(confirmStatements, kind) {
+		answer, err := sh.line.Prompt(fmt.Sprintf("Execute %s statement? [y/N] ", kind))
+		if err != nil && !errors.Is(err, liner.ErrPromptAborted) {
+			return err
+		}
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Fprintln(sh.out, "Cancelled.")
+			return nil
+		}
+	}
+
+	start := time.Now()
+	if utils.ContainsString(queryStatements, kind) {
+		res, err := sh.lcd.Query(sh.ctx, stmt)
+		if err != nil {
+			return err
+		}
+		for _, rs := range res.Results {
+			if err := sh.printResultSet(rs); err != nil {
+				return err
+			}
+			fmt.Fprintf(sh.out, "(%d rows)\n", len(rs.Rows))
+		}
+	} else {
+		if sh.signer == nil {
+			signer, err := newClient()
+			if err != nil {
+				return err
+			}
+			sh.signer = signer
+		}
+		_, res, err := sh.signer.SQLExecResult(sh.ctx, client.CreateTxOptions{}, stmt, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(sh.out, "OK (tx %s, height %d)\n", res.TxHash, res.Height)
+		if kind == "CREATE" || kind == "DROP" || kind == "ALTER" || kind == "RENAME" {
+			sh.catalog.reset()
+		}
+	}
+	if sh.timing {
+		fmt.Fprintf(sh.out, "Time: %.3f ms\n", float64(time.Since(start).Microseconds())/1000)
+	}
+	return nil
+}
+
+func (sh *shell) printResultSet(rs *glittertypes.ResultSet) error {
+	columns := make([]string, len(rs.ColumnDefs))
+	for i, c := range rs.ColumnDefs {
+		columns[i] = c.ColumnName
+	}
+	rows := make([][]interface{}, 0, len(rs.Rows))
+	for _, row := range rs.Rows {
+		values := make([]interface{}, len(row.Columns))
+		for i, v := range row.Columns {
+			values[i] = v
+		}
+		rows = append(rows, values)
+	}
+	return sh.printTable(columns, rows)
+}
+
+func (sh *shell) printTable(columns []string, rows [][]interface{}) error {
+	if sh.expanded {
+		return printExpanded(sh.out, columns, rows)
+	}
+	return printRows(sh.out, outputTable, columns, rows)
+}
+
+// printExpanded print each row as a record of column and value lines
+func printExpanded(w io.Writer, columns []string, rows [][]interface{}) error {
+	width := 0
+	for _, c := range columns {
+		if len(c) > width {
+			width = len(c)
+		}
+	}
+	for n, row := range rows {
+		if _, err := fmt.Fprintf(w, "-[ RECORD %d ]%s\n", n+1, strings.Repeat("-", width)); err != nil {
+			return err
+		}
+		for i, v := range row {
+			fmt.Fprintf(w, "%-*s | %s\n", width, columns[i], formatValue(v))
+		}
+	}
+	return nil
+}
+
+// splitStatements split the complete statements ending with ';' outside quotes and comments,
+// rest is the incomplete statement after the last ';'
+func splitStatements(s string) (statements []string, rest string) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case c == '-' && i+1 < len(s) && s[i+1] == '-':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == ';':
+			if stmt := strings.TrimSpace(s[start:i]); len(stmt) > 0 {
+				statements = append(statements, stmt)
+			}
+			start = i + 1
+		}
+	}
+	return statements, strings.TrimSpace(s[start:])
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func stringRows(values []string) [][]interface{} {
+	rows := make([][]interface{}, len(values))
+	for i, v := range values {
+		rows[i] = []interface{}{v}
+	}
+	return rows
+}
+
+// tableNameRegexp db.table references of a statement
+var tableNameRegexp = regexp.MustCompile("`?(\\w+)`?\\.`?(\\w+)`?")
+
+// catalog cache of the databases, tables and columns completed by the shell
+type catalog struct {
+	ctx           context.Context
+	listDatabases func(ctx context.Context) ([]string, error)
+	listTables    func(ctx context.Context, db string) ([]string, error)
+	tableSchema   func(ctx context.Context, db, table string) (*utils.TableSchema, error)
+
+	mu          sync.Mutex
+	databaseSet []string
+	tableSet    map[string][]string
+	schemas     map[string]*utils.TableSchema
+}
+
+func newCatalog(ctx context.Context, lcd *client.LCDClient) *catalog {
+	c := &catalog{
+		ctx: ctx,
+		listDatabases: func(ctx context.Context) ([]string, error) {
+			res, err := lcd.ListDatabases(ctx, "")
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(res.Databases))
+			for _, d := range res.Databases {
+				names = append(names, d.DatabaseName)
+			}
+			return names, nil
+		},
+		listTables: func(ctx context.Context, db string) ([]string, error) {
+			res, err := lcd.ListTables(ctx, "", "", db, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(res.Tables))
+			for _, t := range res.Tables {
+				names = append(names, t.TableName)
+			}
+			return names, nil
+		},
+		tableSchema: lcd.TableSchema,
+	}
+	c.reset()
+	return c
+}
+
+// reset drop the cached names after a DDL statement
+func (c *catalog) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.databaseSet = nil
+	c.tableSet = map[string][]string{}
+	c.schemas = map[string]*utils.TableSchema{}
+}
+
+func (c *catalog) databases() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.databaseSet == nil {
+		names, err := c.listDatabases(c.ctx)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+		c.databaseSet = names
+	}
+	return c.databaseSet, nil
+}
+
+func (c *catalog) tables(db string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.tableSet[db]; !ok {
+		names, err := c.listTables(c.ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+		c.tableSet[db] = names
+	}
+	return c.tableSet[db], nil
+}
+
+func (c *catalog) schema(db, table string) (*utils.TableSchema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	name := utils.FullTableName(db, table)
+	if _, ok := c.schemas[name]; !ok {
+		schema, err := c.tableSchema(c.ctx, db, table)
+		if err != nil {
+			return nil, err
+		}
+		c.schemas[name] = schema
+	}
+	return c.schemas[name], nil
+}
+
+// complete returns the completions of the word before pos: the tables of db after "db.",
+// otherwise keywords, databases, the tables of the current database and the columns of the
+// tables referenced by the line. Lookup errors only shrink the candidates.
+func (c *catalog) complete(line string, pos int, currentDB string) (head string, completions []string, tail string) {
+	start := pos
+	for start > 0 && !strings.ContainsRune(" \t\n(),=<>", rune(line[start-1])) {
+		start--
+	}
+	head, word, tail := line[:start], line[start:pos], line[pos:]
+
+	var candidates []string
+	if i := strings.IndexByte(word, '.'); i >= 0 {
+		db := strings.Trim(word[:i], "`")
+		tables, _ := c.tables(db)
+		for _, t := range tables {
+			candidates = append(candidates, db+"."+t)
+		}
+	} else {
+		candidates = append(candidates, sqlKeywords...)
+		databases, _ := c.databases()
+		candidates = append(candidates, databases...)
+		if len(currentDB) > 0 {
+			tables, _ := c.tables(currentDB)
+			candidates = append(candidates, tables...)
+		}
+		for _, m := range tableNameRegexp.FindAllStringSubmatch(line, -1) {
+			if schema, err := c.schema(m[1], m[2]); err == nil {
+				for _, col := range schema.Columns {
+					candidates = append(candidates, col.Name)
+				}
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		if seen[candidate] || !strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(word)) {
+			continue
+		}
+		seen[candidate] = true
+		if utils.ContainsString(sqlKeywords, candidate) && len(word) > 0 && strings.ToLower(word) == word {
+			candidate = strings.ToLower(candidate)
+		}
+		completions = append(completions, candidate)
+	}
+	return head, completions, tail
+}
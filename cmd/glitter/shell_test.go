@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitStatements(t *testing.T) {
+	statements, rest := splitStatements("select 1;\nselect ';' from t -- a ; comment\n where a = \"b;\"; insert into")
+	assert.Equal(t, []string{"select 1", "select ';' from t -- a ; comment\n where a = \"b;\""}, statements)
+	assert.Equal(t, "insert into", rest)
+
+	statements, rest = splitStatements("select 'it\\'s;'")
+	assert.Empty(t, statements)
+	assert.Equal(t, "select 'it\\'s;'", rest)
+
+	statements, rest = splitStatements(" ; ;")
+	assert.Empty(t, statements)
+	assert.Empty(t, rest)
+}
+
+func Test_CatalogComplete(t *testing.T) {
+	lookups := 0
+	c := &catalog{
+		ctx: context.Background(),
+		listDatabases: func(ctx context.Context) ([]string, error) {
+			lookups++
+			return []string{"library", "demo"}, nil
+		},
+		listTables: func(ctx context.Context, db string) ([]string, error) {
+			lookups++
+			return map[string][]string{"library": {"ebook", "author"}}[db], nil
+		},
+		tableSchema: func(ctx context.Context, db, table string) (*utils.TableSchema, error) {
+			lookups++
+			return utils.ParseCreateTable("CREATE TABLE `library`.`ebook` (`_id` varchar(255), `title` varchar(255), `tags` varchar(64))")
+		},
+	}
+	c.reset()
+
+	head, completions, tail := c.complete("select * from library.e", 23, "")
+	assert.Equal(t, "select * from ", head)
+	assert.Equal(t, []string{"library.ebook"}, completions)
+	assert.Empty(t, tail)
+
+	line := "select t from library.ebook"
+	head, completions, tail = c.complete(line, 8, "")
+	assert.Equal(t, "select ", head)
+	assert.Equal(t, []string{"table", "tables", "title", "tags"}, completions)
+	assert.Equal(t, " from library.ebook", tail)
+
+	_, completions, _ = c.complete("sel", 3, "library")
+	assert.Equal(t, []string{"select"}, completions)
+	_, completions, _ = c.complete("select * from a", 15, "library")
+	assert.Equal(t, []string{"and", "asc", "as", "alter", "author"}, completions)
+	assert.Equal(t, 3, lookups)
+}
+
+func Test_PrintExpanded(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.NoError(t, printExpanded(out, []string{"_id", "title"}, [][]interface{}{{"1", "Dune"}, {"2", "Emma"}}))
+	assert.Equal(t, `-[ RECORD 1 ]-----
+_id   | 1
+title | Dune
+-[ RECORD 2 ]-----
+_id   | 2
+title | Emma
+`, out.String())
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newTablesCmd() *cobra.Command {
+	var (
+		output, db, keyword, creator string
+		page, pageSize               int
+	)
+	cmd := &cobra.Command{
+		Use:   "tables",
+		Short: "List tables",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var pagePtr, pageSizePtr *int
+			if cmd.Flags().Changed("page") {
+				pagePtr = &page
+			}
+			if cmd.Flags().Changed("page-size") {
+				pageSizePtr = &pageSize
+			}
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.ListTables(cmd.Context(), keyword, creator, db, pagePtr, pageSizePtr)
+			if err != nil {
+				return err
+			}
+			rows := make([][]interface{}, 0, len(res.Tables))
+			for _, t := range res.Tables {
+				rows = append(rows, []interface{}{t.TableName, t.Creator})
+			}
+			return printRows(cmd.OutOrStdout(), output, []string{"table", "creator"}, rows)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	cmd.Flags().StringVar(&db, "db", "", "only list the tables of the database")
+	cmd.Flags().StringVar(&keyword, "keyword", "", "only list the tables matching the keyword")
+	cmd.Flags().StringVar(&creator, "creator", "", "only list the tables created by the address, glitter1... or 0x...")
+	cmd.Flags().IntVar(&page, "page", 1, "page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "tables of a page")
+	return cmd
+}
+
+func newDatabasesCmd() *cobra.Command {
+	var output, creator string
+	cmd := &cobra.Command{
+		Use:   "databases",
+		Short: "List databases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.ListDatabases(cmd.Context(), creator)
+			if err != nil {
+				return err
+			}
+			rows := make([][]interface{}, 0, len(res.Databases))
+			for _, d := range res.Databases {
+				rows = append(rows, []interface{}{d.DatabaseName, d.Creator})
+			}
+			return printRows(cmd.OutOrStdout(), output, []string{"database", "creator"}, rows)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	cmd.Flags().StringVar(&creator, "creator", "", "only list the databases created by the address, glitter1... or 0x...")
+	return cmd
+}
+
+func newShowCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show-create <db> <table>",
+		Short: "Print the CREATE TABLE statement of a table",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.ShowCreateTable(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), res.Schema)
+			return err
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+)
+
+func newTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Inspect txs",
+	}
+	cmd.AddCommand(newTxStatusCmd(), newTxWaitCmd())
+	return cmd
+}
+
+func newTxStatusCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "status <hash>",
+		Short: "Print the result of a committed tx",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.GetTx(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printTxResponse(cmd, output, res.TxResponse)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	return cmd
+}
+
+func newTxWaitCmd() *cobra.Command {
+	var (
+		output  string
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "wait <hash>",
+		Short: "Wait until a tx is committed and print its result",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			lcd, err := newQueryClient()
+			if err != nil {
+				return err
+			}
+			res, err := lcd.WaitTx(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printTxResponse(cmd, output, res.TxResponse)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "max time to wait")
+	return cmd
+}
+
+// printTxResponse print the result of a broadcast or committed tx
+func printTxResponse(cmd *cobra.Command, output string, txResponse *sdk.TxResponse) error {
+	return printRows(cmd.OutOrStdout(), output,
+		[]string{"tx_hash", "height", "code", "codespace", "gas_wanted", "gas_used", "raw_log"},
+		[][]interface{}{{txResponse.TxHash, txResponse.Height, txResponse.Code, txResponse.Codespace,
+			txResponse.GasWanted, txResponse.GasUsed, txResponse.RawLog}})
+}
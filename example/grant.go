@@ -1,16 +1,52 @@
 package main
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/example/testclient"
+	"github.com/glitternetwork/glitter-sdk-go/example/testdata"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+)
+
 func main() {
-	//address = MnemonicKey(mnemonic_key, 0, 1).acc_address
-	//print(address)
-	//
-	//# grant table writer role to address
-	//print("=====grant table writer:")
-	//rst = db_client.grant_writer(address, db_name, book_tb_name)
-	//print(rst)
-	//
-	//# grant database admin role to address
-	//print("=====grant database admin")
-	//rst = db_client.grant_admin(address, db_name)
-	//print(rst)
+	cli := testclient.New()
+	ctx := context.TODO()
+
+	wallet, err := key.NewHDWallet(testclient.Mnemonic)
+	if err != nil {
+		panic(err)
+	}
+	acc, err := wallet.Derive(0, 1)
+	if err != nil {
+		panic(err)
+	}
+	address := acc.Bech32Address()
+	fmt.Println(address)
+
+	// grant table writer role to address
+	fmt.Println("=====grant table writer:")
+	resp, err := cli.GrantWriter(ctx, testdata.TestDBName, testdata.TestTableNameBook, address)
+	fmt.Printf("response=%+v,err=%+v\n", resp, err)
+
+	// grant database admin role to address
+	fmt.Println("=====grant database admin:")
+	resp, err = cli.GrantAdmin(ctx, testdata.TestDBName, "", address)
+	fmt.Printf("response=%+v,err=%+v\n", resp, err)
+
+	fmt.Println("=====list grants:")
+	grants, err := cli.ListSQLGrants(ctx, testdata.TestDBName, testdata.TestTableNameBook)
+	for _, g := range grants {
+		fmt.Printf("%s\t%s\t%s\t%s\n", g.Database, g.Table, g.UID, g.Role)
+	}
+	fmt.Printf("err=%+v\n", err)
+
+	allowed, err := cli.CheckPermission(ctx, address, testdata.TestDBName, testdata.TestTableNameBook, client.ActionInsert)
+	fmt.Printf("can insert=%v,err=%+v\n", allowed, err)
+
+	// revoke database admin role from address
+	fmt.Println("=====revoke database admin:")
+	resp, err = cli.SQLRevoke(ctx, testdata.TestDBName, "", address, client.GrantOwner)
+	fmt.Printf("response=%+v,err=%+v\n", resp, err)
 }
@@ -2,20 +2,21 @@ package testclient
 
 import (
 	"github.com/glitternetwork/glitter-sdk-go/client"
-	"github.com/glitternetwork/glitter-sdk-go/key"
 )
 
+// Mnemonic of the test account
+const Mnemonic = "lesson police usual earth embrace someone opera season urban produce jealous canyon shrug usage subject cigar imitate hollow route inhale vocal special sun fuel"
+
+// New create client of the test account on the mainnet profile, overridden by the GLITTER_* env
 func New() *client.LCDClient {
-	const chainID = "glitter_12000-2"
-	mnemonicKey := "lesson police usual earth embrace someone opera season urban produce jealous canyon shrug usage subject cigar imitate hollow route inhale vocal special sun fuel"
-	pk, err := key.DerivePrivKeyBz(mnemonicKey, key.CreateHDPath(0, 0))
+	profile, err := (&client.Config{}).Profile(client.NetworkMainnet)
 	if err != nil {
 		panic(err)
 	}
-
-	privKey, err := key.PrivKeyGen(pk)
+	profile.Mnemonic = Mnemonic
+	lcd, err := client.NewFromConfig(profile)
 	if err != nil {
 		panic(err)
 	}
-	return client.New(chainID, privKey)
+	return lcd
 }
@@ -0,0 +1,308 @@
+// Package exporter stream query results and tables to CSV, JSON Lines and Parquet files
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// DefaultPageSize rows queried at a time when exporting a table
+const DefaultPageSize = 1000
+
+// Format output file format
+type Format string
+
+// supported formats
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+// FormatOf returns the format of path by its extension
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".parquet":
+		return FormatParquet, nil
+	}
+	return "", fmt.Errorf("unknown format of %s, expected .csv, .jsonl or .parquet", path)
+}
+
+// Config config of an Exporter
+type Config struct {
+	// Format output format
+	Format Format
+	// Comma CSV field delimiter, default to ','
+	Comma rune
+	// NoHeader do not write the CSV header line
+	NoHeader bool
+	// PageSize rows queried at a time when exporting a table, default to DefaultPageSize
+	PageSize int
+	// RowGroupRows rows of a Parquet row group, default to DefaultRowGroupRows
+	RowGroupRows int
+	// OnProgress called after each page of rows is written
+	OnProgress func(stats Stats)
+}
+
+// Stats result of an export
+type Stats struct {
+	// Rows rows written
+	Rows int64
+	// Queries queries sent
+	Queries int
+	// Elapsed time since the export started
+	Elapsed time.Duration
+}
+
+// RowsPerSecond returns the export throughput
+func (s Stats) RowsPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Rows) / s.Elapsed.Seconds()
+}
+
+// Exporter query rows and write them to an output format
+type Exporter struct {
+	lcd    *client.LCDClient
+	config Config
+}
+
+// New create exporter querying with lcd
+func New(lcd *client.LCDClient, config Config) (*Exporter, error) {
+	if config.Format != FormatCSV && config.Format != FormatJSONL && config.Format != FormatParquet {
+		return nil, fmt.Errorf("unsupported format %q", config.Format)
+	}
+	if config.Comma == 0 {
+		config.Comma = ','
+	}
+	if config.PageSize <= 0 {
+		config.PageSize = DefaultPageSize
+	}
+	if config.RowGroupRows <= 0 {
+		config.RowGroupRows = DefaultRowGroupRows
+	}
+	return &Exporter{lcd: lcd, config: config}, nil
+}
+
+// NewWriter create the row writer of the configured format writing to w
+func (e *Exporter) NewWriter(w io.Writer) Writer {
+	switch e.config.Format {
+	case FormatJSONL:
+		return NewJSONLWriter(w)
+	case FormatParquet:
+		return NewParquetWriter(w, e.config.RowGroupRows)
+	default:
+		return NewCSVWriter(w, e.config.Comma, e.config.NoHeader)
+	}
+}
+
+// ExportQuery run a SELECT statement and write its rows to w
+func (e *Exporter) ExportQuery(ctx context.Context, w io.Writer, sql string, args ...*glittertypes.Argument) (Stats, error) {
+	start := time.Now()
+	stats := Stats{}
+	out := e.NewWriter(w)
+
+	res, err := e.lcd.Query(ctx, sql, args...)
+	stats.Queries++
+	if err != nil {
+		return stats, err
+	}
+	var columns []*glittertypes.ColumnDef
+	for _, rs := range res.Results {
+		if columns == nil && len(rs.ColumnDefs) > 0 {
+			columns = rs.ColumnDefs
+			if err := out.WriteHeader(columns); err != nil {
+				return stats, err
+			}
+		}
+		n, err := writeRows(out, columns, rs.Rows)
+		stats.Rows += n
+		if err != nil {
+			return stats, err
+		}
+	}
+	if columns == nil {
+		if err := out.WriteHeader(nil); err != nil {
+			return stats, err
+		}
+	}
+	stats.Elapsed = time.Since(start)
+	e.progress(stats)
+	return stats, out.Close()
+}
+
+// ExportTable write all rows of db.table to w. Pages are read in primary key order after the
+// last exported key when the table has a single column primary key, by offset otherwise.
+func (e *Exporter) ExportTable(ctx context.Context, w io.Writer, db, table string) (Stats, error) {
+	start := time.Now()
+	stats := Stats{}
+	out := e.NewWriter(w)
+
+	schema, err := e.lcd.TableSchema(ctx, db, table)
+	if err != nil {
+		return stats, err
+	}
+	key := primaryKey(schema)
+
+	var (
+		columns []*glittertypes.ColumnDef
+		keyIdx  = -1
+		cursor  *glittertypes.Argument
+		offset  int
+	)
+	for {
+		sql, args := pageQuery(db, table, key, cursor, offset, e.config.PageSize)
+		res, err := e.lcd.Query(ctx, sql, args...)
+		stats.Queries++
+		if err != nil {
+			return stats, err
+		}
+
+		var rows []*glittertypes.RowData
+		for _, rs := range res.Results {
+			if columns == nil && len(rs.ColumnDefs) > 0 {
+				columns = rs.ColumnDefs
+				if err := out.WriteHeader(columns); err != nil {
+					return stats, err
+				}
+				keyIdx = columnIndex(columns, key)
+			}
+			rows = append(rows, rs.Rows...)
+		}
+		n, err := writeRows(out, columns, rows)
+		stats.Rows += n
+		if err != nil {
+			return stats, err
+		}
+		stats.Elapsed = time.Since(start)
+		e.progress(stats)
+
+		if len(rows) < e.config.PageSize {
+			break
+		}
+		if len(key) > 0 {
+			if keyIdx < 0 {
+				return stats, fmt.Errorf("primary key %s is not in the result columns", key)
+			}
+			cursor = keyArgument(columns[keyIdx], rows[len(rows)-1].Columns[keyIdx])
+		} else {
+			offset += len(rows)
+		}
+	}
+
+	if columns == nil {
+		if err := out.WriteHeader(schemaColumns(schema)); err != nil {
+			return stats, err
+		}
+	}
+	return stats, out.Close()
+}
+
+func (e *Exporter) progress(stats Stats) {
+	if e.config.OnProgress != nil {
+		e.config.OnProgress(stats)
+	}
+}
+
+// writeRows decode rows by the column value types and write them to out
+func writeRows(out Writer, columns []*glittertypes.ColumnDef, rows []*glittertypes.RowData) (int64, error) {
+	var n int64
+	for _, row := range rows {
+		if len(row.Columns) != len(columns) {
+			return n, fmt.Errorf("row has %d values, expected %d columns", len(row.Columns), len(columns))
+		}
+		values := make([]interface{}, len(columns))
+		for i, s := range row.Columns {
+			v, err := DecodeValue(columns[i].ColumnValueType, s)
+			if err != nil {
+				return n, fmt.Errorf("column %s: %w", columns[i].ColumnName, err)
+			}
+			values[i] = v
+		}
+		if err := out.WriteRow(values); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// primaryKey returns the primary key column of the schema, "" if the key is not a single column
+func primaryKey(schema *utils.TableSchema) string {
+	key := ""
+	for _, c := range schema.Columns {
+		if c.PrimaryKey {
+			if len(key) > 0 {
+				return ""
+			}
+			key = c.Name
+		}
+	}
+	return key
+}
+
+// pageQuery returns the query of the page after cursor, or at offset if the table has no key
+func pageQuery(db, table, key string, cursor *glittertypes.Argument, offset, pageSize int) (string, []*glittertypes.Argument) {
+	name := utils.FullTableName(db, table)
+	if len(key) == 0 {
+		return fmt.Sprintf("select * from %s limit %d, %d", name, offset, pageSize), nil
+	}
+	if cursor == nil {
+		return fmt.Sprintf("select * from %s order by %s asc limit %d", name, key, pageSize), nil
+	}
+	return fmt.Sprintf("select * from %s where %s > ? order by %s asc limit %d", name, key, key, pageSize),
+		[]*glittertypes.Argument{cursor}
+}
+
+// keyArgument returns the query argument of a raw key value of the result
+func keyArgument(column *glittertypes.ColumnDef, value string) *glittertypes.Argument {
+	argType := glittertypes.Argument_STRING
+	switch column.ColumnValueType {
+	case glittertypes.ColumnValueType_IntColumn:
+		argType = glittertypes.Argument_INT
+	case glittertypes.ColumnValueType_UintColumn:
+		argType = glittertypes.Argument_UINT
+	case glittertypes.ColumnValueType_FloatColumn:
+		argType = glittertypes.Argument_FLOAT
+	case glittertypes.ColumnValueType_BoolColumn:
+		argType = glittertypes.Argument_BOOL
+	case glittertypes.ColumnValueType_BytesColumn:
+		argType = glittertypes.Argument_BYTES
+	}
+	return &glittertypes.Argument{Type: argType, Value: value}
+}
+
+func columnIndex(columns []*glittertypes.ColumnDef, name string) int {
+	for i, c := range columns {
+		if strings.EqualFold(c.ColumnName, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// schemaColumns returns the column defs of the schema, used for the header of an empty table
+func schemaColumns(schema *utils.TableSchema) []*glittertypes.ColumnDef {
+	columns := make([]*glittertypes.ColumnDef, 0, len(schema.Columns))
+	for i := range schema.Columns {
+		columns = append(columns, &glittertypes.ColumnDef{
+			ColumnName:      schema.Columns[i].Name,
+			ColumnType:      schema.Columns[i].Type,
+			ColumnValueType: schema.Columns[i].ValueType(),
+		})
+	}
+	return columns
+}
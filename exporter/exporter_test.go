@@ -0,0 +1,148 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ebookColumns = []*glittertypes.ColumnDef{
+	{ColumnName: "_id", ColumnType: "INT", ColumnValueType: glittertypes.ColumnValueType_IntColumn},
+	{ColumnName: "title", ColumnType: "VARCHAR", ColumnValueType: glittertypes.ColumnValueType_StringColumn},
+	{ColumnName: "size", ColumnType: "INT", ColumnValueType: glittertypes.ColumnValueType_UintColumn},
+	{ColumnName: "rating", ColumnType: "DOUBLE", ColumnValueType: glittertypes.ColumnValueType_FloatColumn},
+	{ColumnName: "free", ColumnType: "BOOLEAN", ColumnValueType: glittertypes.ColumnValueType_BoolColumn},
+	{ColumnName: "cover", ColumnType: "BLOB", ColumnValueType: glittertypes.ColumnValueType_BytesColumn},
+}
+
+var ebookRows = [][]string{
+	{"1", "Dune", "1024", "4.5", "true", "aGk="},
+	{"2", "Emma, \"a novel\"", "", "3", "false", ""},
+	{"3", "Walden", "2048", "", "", "AAE="},
+}
+
+func Test_PageQuery(t *testing.T) {
+	sql, args := pageQuery("library", "ebook", "_id", nil, 0, 2)
+	assert.Equal(t, "select * from library.ebook order by _id asc limit 2", sql)
+	assert.Empty(t, args)
+
+	cursor := keyArgument(ebookColumns[0], ebookRows[1][0])
+	sql, args = pageQuery("library", "ebook", "_id", cursor, 0, 2)
+	assert.Equal(t, "select * from library.ebook where _id > ? order by _id asc limit 2", sql)
+	assert.Equal(t, []*glittertypes.Argument{{Type: glittertypes.Argument_INT, Value: "2"}}, args)
+
+	sql, args = pageQuery("library", "ebook", "", nil, 4, 2)
+	assert.Equal(t, "select * from library.ebook limit 4, 2", sql)
+	assert.Empty(t, args)
+}
+
+func exportRows(t *testing.T, w Writer) {
+	assert.NoError(t, w.WriteHeader(ebookColumns))
+	rows := make([]*glittertypes.RowData, 0, len(ebookRows))
+	for _, row := range ebookRows {
+		rows = append(rows, &glittertypes.RowData{Columns: row})
+	}
+	n, err := writeRows(w, ebookColumns, rows)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(ebookRows)), n)
+	assert.NoError(t, w.Close())
+}
+
+func Test_CSVWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	exportRows(t, NewCSVWriter(out, ',', false))
+	assert.Equal(t, `_id,title,size,rating,free,cover
+1,Dune,1024,4.5,true,aGk=
+2,"Emma, ""a novel""",,3,false,
+3,Walden,2048,,,AAE=
+`, out.String())
+
+	out.Reset()
+	exportRows(t, NewCSVWriter(out, '\t', true))
+	assert.Equal(t, "1\tDune\t1024\t4.5\ttrue\taGk=\n2\t\"Emma, \"\"a novel\"\"\"\t\t3\tfalse\t\n3\tWalden\t2048\t\t\tAAE=\n", out.String())
+}
+
+func Test_JSONLWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	exportRows(t, NewJSONLWriter(out))
+	assert.Equal(t, `{"_id":1,"title":"Dune","size":1024,"rating":4.5,"free":true,"cover":"aGk="}
+{"_id":2,"title":"Emma, \"a novel\"","size":null,"rating":3,"free":false,"cover":""}
+{"_id":3,"title":"Walden","size":2048,"rating":null,"free":null,"cover":"AAE="}
+`, out.String())
+
+	_, err := writeRows(NewJSONLWriter(out), ebookColumns[:1], []*glittertypes.RowData{{Columns: []string{"x"}}})
+	assert.EqualError(t, err, `column _id: strconv.ParseInt: parsing "x": invalid syntax`)
+}
+
+func Test_ParquetWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	exportRows(t, NewParquetWriter(out, 2))
+
+	pf, err := file.NewParquetReader(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+	defer pf.Close()
+	assert.Equal(t, int64(3), pf.NumRows())
+	assert.Equal(t, 2, pf.NumRowGroups())
+	assert.Equal(t, int64(2), pf.RowGroup(0).NumRows())
+	schema := pf.MetaData().Schema
+	require.Equal(t, 6, schema.NumColumns())
+	types := map[string]string{}
+	for i := 0; i < schema.NumColumns(); i++ {
+		column := schema.Column(i)
+		types[column.Name()] = column.PhysicalType().String() + " " + column.LogicalType().String()
+	}
+	assert.Equal(t, map[string]string{
+		"_id":    "INT64 Int(bitWidth=64, isSigned=true)",
+		"title":  "BYTE_ARRAY String",
+		"size":   "INT64 Int(bitWidth=64, isSigned=false)",
+		"rating": "DOUBLE None",
+		"free":   "BOOLEAN None",
+		"cover":  "BYTE_ARRAY None",
+	}, types)
+
+	reader, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	table, err := reader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+	values := func(column int) []interface{} {
+		var values []interface{}
+		for _, chunk := range table.Column(column).Data().Chunks() {
+			for i := 0; i < chunk.Len(); i++ {
+				if chunk.IsNull(i) {
+					values = append(values, nil)
+					continue
+				}
+				switch chunk := chunk.(type) {
+				case *array.Int64:
+					values = append(values, chunk.Value(i))
+				case *array.Uint64:
+					values = append(values, chunk.Value(i))
+				case *array.Float64:
+					values = append(values, chunk.Value(i))
+				case *array.Boolean:
+					values = append(values, chunk.Value(i))
+				case *array.String:
+					values = append(values, chunk.Value(i))
+				case *array.Binary:
+					values = append(values, chunk.Value(i))
+				}
+			}
+		}
+		return values
+	}
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, values(0))
+	assert.Equal(t, []interface{}{"Dune", "Emma, \"a novel\"", "Walden"}, values(1))
+	assert.Equal(t, []interface{}{uint64(1024), nil, uint64(2048)}, values(2))
+	assert.Equal(t, []interface{}{4.5, float64(3), nil}, values(3))
+	assert.Equal(t, []interface{}{true, false, nil}, values(4))
+	assert.Equal(t, []interface{}{[]byte("hi"), []byte{}, []byte{0, 1}}, values(5))
+}
@@ -0,0 +1,160 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// DefaultRowGroupRows rows of a Parquet row group
+const DefaultRowGroupRows = 10000
+
+// parquetWriter write rows as a snappy compressed Parquet file with the arrow parquet writer.
+// Every column is nullable, nil values are written as nulls.
+//
+// Column value types map to Arrow types as:
+//   - INT: int64
+//   - UINT: uint64
+//   - FLOAT: float64
+//   - BOOL: bool
+//   - STRING: utf8
+//   - BYTES: binary holding the base64 decoded bytes
+type parquetWriter struct {
+	w            io.Writer
+	rowGroupRows int
+
+	columns []*glittertypes.ColumnDef
+	builder *array.RecordBuilder
+	fw      *pqarrow.FileWriter
+	rows    int
+}
+
+// NewParquetWriter create Parquet writer flushing a row group every rowGroupRows rows
+func NewParquetWriter(w io.Writer, rowGroupRows int) Writer {
+	if rowGroupRows <= 0 {
+		rowGroupRows = DefaultRowGroupRows
+	}
+	return &parquetWriter{w: w, rowGroupRows: rowGroupRows}
+}
+
+func (p *parquetWriter) WriteHeader(columns []*glittertypes.ColumnDef) error {
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = arrow.Field{Name: column.ColumnName, Type: arrowType(column.ColumnValueType), Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil)
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Snappy),
+		parquet.WithMaxRowGroupLength(int64(p.rowGroupRows)),
+		parquet.WithCreatedBy("glitter-sdk-go"),
+	)
+	// the file writer closes its sink, hide the Close of the underlying writer
+	fw, err := pqarrow.NewFileWriter(schema, struct{ io.Writer }{p.w}, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	p.columns = columns
+	p.builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	p.fw = fw
+	return nil
+}
+
+func arrowType(valueType glittertypes.ColumnValueType) arrow.DataType {
+	switch valueType {
+	case glittertypes.ColumnValueType_IntColumn:
+		return arrow.PrimitiveTypes.Int64
+	case glittertypes.ColumnValueType_UintColumn:
+		return arrow.PrimitiveTypes.Uint64
+	case glittertypes.ColumnValueType_FloatColumn:
+		return arrow.PrimitiveTypes.Float64
+	case glittertypes.ColumnValueType_BoolColumn:
+		return arrow.FixedWidthTypes.Boolean
+	case glittertypes.ColumnValueType_BytesColumn:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func (p *parquetWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		if err := appendValue(p.builder.Field(i), v); err != nil {
+			return fmt.Errorf("column %s: %w", p.columns[i].ColumnName, err)
+		}
+	}
+	p.rows++
+	if p.rows >= p.rowGroupRows {
+		return p.flushRowGroup()
+	}
+	return nil
+}
+
+// appendValue append a value decoded by DecodeValue to the builder of its column
+func appendValue(b array.Builder, v interface{}) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+	ok := true
+	switch b := b.(type) {
+	case *array.Int64Builder:
+		var n int64
+		if n, ok = v.(int64); ok {
+			b.Append(n)
+		}
+	case *array.Uint64Builder:
+		var n uint64
+		if n, ok = v.(uint64); ok {
+			b.Append(n)
+		}
+	case *array.Float64Builder:
+		var f float64
+		if f, ok = v.(float64); ok {
+			b.Append(f)
+		}
+	case *array.BooleanBuilder:
+		var bv bool
+		if bv, ok = v.(bool); ok {
+			b.Append(bv)
+		}
+	case *array.StringBuilder:
+		var s string
+		if s, ok = v.(string); ok {
+			b.Append(s)
+		}
+	case *array.BinaryBuilder:
+		var bz []byte
+		if bz, ok = v.([]byte); ok {
+			b.Append(bz)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("cannot write %T as %s", v, b.Type())
+	}
+	return nil
+}
+
+// flushRowGroup write the buffered rows as a row group
+func (p *parquetWriter) flushRowGroup() error {
+	rec := p.builder.NewRecord()
+	defer rec.Release()
+	p.rows = 0
+	return p.fw.Write(rec)
+}
+
+func (p *parquetWriter) Close() error {
+	if p.rows > 0 {
+		if err := p.flushRowGroup(); err != nil {
+			return err
+		}
+	}
+	p.builder.Release()
+	return p.fw.Close()
+}
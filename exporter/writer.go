@@ -0,0 +1,154 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// Writer write decoded rows in an output format
+type Writer interface {
+	// WriteHeader write the columns of the rows, called once before WriteRow
+	WriteHeader(columns []*glittertypes.ColumnDef) error
+	// WriteRow write a row of values returned by DecodeValue
+	WriteRow(values []interface{}) error
+	// Close flush the buffered rows, the underlying writer is not closed
+	Close() error
+}
+
+// DecodeValue decode the string value of a result column by its value type.
+// INT, UINT, FLOAT and BOOL values decode to int64, uint64, float64 and bool, empty values to nil.
+// BYTES values are base64 decoded to []byte, other values are returned as string.
+func DecodeValue(valueType glittertypes.ColumnValueType, s string) (interface{}, error) {
+	switch valueType {
+	case glittertypes.ColumnValueType_IntColumn:
+		if len(s) == 0 {
+			return nil, nil
+		}
+		return strconv.ParseInt(s, 10, 64)
+	case glittertypes.ColumnValueType_UintColumn:
+		if len(s) == 0 {
+			return nil, nil
+		}
+		return strconv.ParseUint(s, 10, 64)
+	case glittertypes.ColumnValueType_FloatColumn:
+		if len(s) == 0 {
+			return nil, nil
+		}
+		return strconv.ParseFloat(s, 64)
+	case glittertypes.ColumnValueType_BoolColumn:
+		if len(s) == 0 {
+			return nil, nil
+		}
+		return strconv.ParseBool(s)
+	case glittertypes.ColumnValueType_BytesColumn:
+		return base64.StdEncoding.DecodeString(s)
+	default:
+		return s, nil
+	}
+}
+
+// csvWriter write rows as CSV, BYTES values are written base64 encoded
+type csvWriter struct {
+	w        *csv.Writer
+	noHeader bool
+	record   []string
+}
+
+// NewCSVWriter create CSV writer with the comma delimiter
+func NewCSVWriter(w io.Writer, comma rune, noHeader bool) Writer {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &csvWriter{w: cw, noHeader: noHeader}
+}
+
+func (c *csvWriter) WriteHeader(columns []*glittertypes.ColumnDef) error {
+	c.record = make([]string, len(columns))
+	if c.noHeader || len(columns) == 0 {
+		return nil
+	}
+	for i, column := range columns {
+		c.record[i] = column.ColumnName
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		c.record[i] = formatText(v)
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// formatText returns the CSV text of a decoded value
+func formatText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonlWriter write rows as JSON objects keyed by column name in column order,
+// BYTES values are written base64 encoded
+type jsonlWriter struct {
+	w     *bufio.Writer
+	names [][]byte
+}
+
+// NewJSONLWriter create JSON Lines writer
+func NewJSONLWriter(w io.Writer) Writer {
+	return &jsonlWriter{w: bufio.NewWriter(w)}
+}
+
+func (j *jsonlWriter) WriteHeader(columns []*glittertypes.ColumnDef) error {
+	j.names = make([][]byte, len(columns))
+	for i, column := range columns {
+		name, err := json.Marshal(column.ColumnName)
+		if err != nil {
+			return err
+		}
+		j.names[i] = name
+	}
+	return nil
+}
+
+func (j *jsonlWriter) WriteRow(values []interface{}) error {
+	j.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			j.w.WriteByte(',')
+		}
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", j.names[i], err)
+		}
+		j.w.Write(j.names[i])
+		j.w.WriteByte(':')
+		j.w.Write(bz)
+	}
+	_, err := j.w.WriteString("}\n")
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// integerBits bits of the sql integer types
+var integerBits = map[string]int{
+	"TINYINT":   8,
+	"SMALLINT":  16,
+	"MEDIUMINT": 24,
+	"INT":       32,
+	"INTEGER":   32,
+	"BIGINT":    64,
+}
+
+// Coerce convert a source value to the go type inserted into column.
+// value is a CSV field string or a value decoded from JSON with UseNumber.
+// BYTES values are base64 encoded strings, null values are rejected.
+func Coerce(column *utils.ColumnSchema, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("column %s: null is not supported", column.Name)
+	}
+
+	switch column.ValueType() {
+	case glittertypes.ColumnValueType_IntColumn:
+		s, err := numberString(column, value)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseInt(s, 10, integerBits[column.Type])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: invalid %s %q", column.Name, column.Type, s)
+		}
+		return v, nil
+	case glittertypes.ColumnValueType_UintColumn:
+		s, err := numberString(column, value)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseUint(s, 10, integerBits[column.Type])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: invalid %s UNSIGNED %q", column.Name, column.Type, s)
+		}
+		return v, nil
+	case glittertypes.ColumnValueType_FloatColumn:
+		s, err := numberString(column, value)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: invalid %s %q", column.Name, column.Type, s)
+		}
+		return v, nil
+	case glittertypes.ColumnValueType_BoolColumn:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string, json.Number:
+			b, err := strconv.ParseBool(fmt.Sprint(v))
+			if err != nil {
+				return nil, fmt.Errorf("column %s: invalid BOOL %q", column.Name, v)
+			}
+			return b, nil
+		}
+	case glittertypes.ColumnValueType_BytesColumn:
+		if s, ok := value.(string); ok {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: invalid base64 %s value", column.Name, column.Type)
+			}
+			if column.Length > 0 && len(b) > column.Length {
+				return nil, fmt.Errorf("column %s: %d bytes exceeds %s(%d)", column.Name, len(b), column.Type, column.Length)
+			}
+			return b, nil
+		}
+	default:
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case json.Number:
+			s = v.String()
+		case bool:
+			s = strconv.FormatBool(v)
+		default:
+			bz, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", column.Name, err)
+			}
+			s = string(bz)
+		}
+		if (column.Type == "VARCHAR" || column.Type == "CHAR") && column.Length > 0 && utf8.RuneCountInString(s) > column.Length {
+			return nil, fmt.Errorf("column %s: %d characters exceeds %s(%d)", column.Name, utf8.RuneCountInString(s), column.Type, column.Length)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("column %s: cannot convert %T to %s", column.Name, value, column.Type)
+}
+
+// numberString returns the text of a numeric source value
+func numberString(column *utils.ColumnSchema, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			return "", fmt.Errorf("column %s: empty %s value", column.Name, column.Type)
+		}
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("column %s: cannot convert %T to %s", column.Name, value, column.Type)
+}
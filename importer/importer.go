@@ -0,0 +1,458 @@
+// Package importer load CSV and JSON Lines files into glitter tables
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glitternetwork/glitter-sdk-go/client"
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+)
+
+// maxJSONLineBytes max bytes of a JSON Lines record
+const maxJSONLineBytes = 16 * 1024 * 1024
+
+// Format source file format
+type Format string
+
+// supported formats
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// FormatOf returns the format of path by its extension
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	}
+	return "", fmt.Errorf("unknown format of %s, expected .csv or .jsonl", path)
+}
+
+// Config config of an Importer
+type Config struct {
+	// Format source format
+	Format Format
+	// Comma CSV field delimiter, default to ','
+	Comma rune
+	// NoHeader the CSV has no header line, Columns names its fields in order
+	NoHeader bool
+	// Columns source fields to import, default to the CSV header or the keys of the first JSON record
+	Columns []string
+	// Mapping rename source fields to table columns, fields mapped to "" are not imported
+	Mapping map[string]string
+	// RejectFile file receiving the records failing validation, rejected records are dropped if empty
+	RejectFile string
+	// Load chunking, concurrency, retry and checkpoint config of the inserts
+	Load client.BulkLoadConfig
+}
+
+// Stats result of an import
+type Stats struct {
+	client.BulkLoadStats
+	// Rejected records failing validation
+	Rejected int64
+}
+
+// Importer validate records against the table schema and insert them in chunks
+type Importer struct {
+	lcd    *client.LCDClient
+	schema *utils.TableSchema
+	config Config
+}
+
+// New create importer into db.table, the table schema is read with ShowCreateTable
+func New(ctx context.Context, lcd *client.LCDClient, db, table string, config Config) (*Importer, error) {
+	schema, err := lcd.TableSchema(ctx, db, table)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithSchema(lcd, schema, config)
+}
+
+// NewWithSchema create importer into the table of schema
+func NewWithSchema(lcd *client.LCDClient, schema *utils.TableSchema, config Config) (*Importer, error) {
+	if config.Format != FormatCSV && config.Format != FormatJSONL {
+		return nil, fmt.Errorf("unsupported format %q", config.Format)
+	}
+	if config.Comma == 0 {
+		config.Comma = ','
+	}
+	if config.NoHeader && len(config.Columns) == 0 {
+		return nil, fmt.Errorf("columns are required for CSV without header")
+	}
+	return &Importer{
+		lcd:    lcd,
+		schema: schema,
+		config: config,
+	}, nil
+}
+
+// ImportFile import the records of the file at path
+func (im *Importer) ImportFile(ctx context.Context, path string) (Stats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer f.Close()
+	return im.Import(ctx, f)
+}
+
+// Import validate and insert the records of r, records failing validation are written to the reject file
+func (im *Importer) Import(ctx context.Context, r io.Reader) (Stats, error) {
+	var rejects *rejectWriter
+	if len(im.config.RejectFile) > 0 {
+		f, err := os.Create(im.config.RejectFile)
+		if err != nil {
+			return Stats{}, err
+		}
+		defer f.Close()
+		rejects = &rejectWriter{w: f, format: im.config.Format, comma: im.config.Comma}
+	}
+
+	var src *source
+	var err error
+	if im.config.Format == FormatCSV {
+		src, err = im.newCSVSource(r, rejects)
+	} else {
+		src, err = im.newJSONLSource(r, rejects)
+	}
+	if err != nil {
+		return Stats{}, err
+	}
+
+	loader := im.lcd.NewBulkLoader(im.schema.Database, im.schema.Table, src.tableColumns, im.config.Load)
+	loadStats, err := loader.Load(ctx, src)
+	stats := Stats{BulkLoadStats: loadStats, Rejected: src.rejected}
+	if rejects != nil {
+		if flushErr := rejects.flush(); err == nil {
+			err = flushErr
+		}
+	}
+	return stats, err
+}
+
+// source client.RowSource of validated rows, invalid records are rejected
+type source struct {
+	schema       *utils.TableSchema
+	fields       []string
+	tableColumns []string
+	read         func() (line int64, raw []string, values map[string]interface{}, err error)
+	rejects      *rejectWriter
+	rejected     int64
+}
+
+// parseError error of a malformed record, the record is rejected and the import goes on
+type parseError struct {
+	err error
+}
+
+func (e *parseError) Error() string {
+	return e.err.Error()
+}
+
+// Next returns the next valid row in the order of tableColumns
+func (s *source) Next() ([]interface{}, error) {
+	for {
+		line, raw, values, err := s.read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		var pe *parseError
+		if errors.As(err, &pe) {
+			if err := s.reject(line, raw, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row, err := s.coerce(values)
+		if err != nil {
+			if err := s.reject(line, raw, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return row, nil
+	}
+}
+
+func (s *source) coerce(values map[string]interface{}) ([]interface{}, error) {
+	row := make([]interface{}, len(s.tableColumns))
+	for i, name := range s.tableColumns {
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("column %s: missing value", name)
+		}
+		v, err := Coerce(s.schema.Column(name), value)
+		if err != nil {
+			return nil, err
+		}
+		row[i] = v
+	}
+	return row, nil
+}
+
+func (s *source) reject(line int64, raw []string, reason error) error {
+	s.rejected++
+	if s.rejects == nil {
+		return nil
+	}
+	return s.rejects.write(s.fields, line, raw, reason)
+}
+
+// resolveColumns map source fields to table columns, nil entries are not imported
+func (im *Importer) resolveColumns(fields []string) ([]*utils.ColumnSchema, error) {
+	columns := make([]*utils.ColumnSchema, len(fields))
+	for i, field := range fields {
+		name := field
+		if mapped, ok := im.config.Mapping[field]; ok {
+			if len(mapped) == 0 {
+				continue
+			}
+			name = mapped
+		}
+		column := im.schema.Column(name)
+		if column == nil {
+			return nil, fmt.Errorf("table %s has no column %s for field %s", utils.FullTableName(im.schema.Database, im.schema.Table), name, field)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+func columnNames(columns []*utils.ColumnSchema) []string {
+	var names []string
+	for _, c := range columns {
+		if c != nil {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+func (im *Importer) newCSVSource(r io.Reader, rejects *rejectWriter) (*source, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = im.config.Comma
+	reader.FieldsPerRecord = -1
+
+	fields := im.config.Columns
+	if !im.config.NoHeader {
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		if len(fields) == 0 {
+			fields = header
+		}
+		if len(fields) != len(header) {
+			return nil, fmt.Errorf("%d columns for %d CSV header fields", len(fields), len(header))
+		}
+	}
+	columns, err := im.resolveColumns(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{schema: im.schema, fields: fields, tableColumns: columnNames(columns), rejects: rejects}
+	src.read = func() (int64, []string, map[string]interface{}, error) {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return 0, nil, nil, io.EOF
+		}
+		var csvErr *csv.ParseError
+		if errors.As(err, &csvErr) {
+			return int64(csvErr.StartLine), record, nil, &parseError{err: err}
+		}
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		startLine, _ := reader.FieldPos(0)
+		line := int64(startLine)
+		if len(record) != len(fields) {
+			return line, record, nil, &parseError{err: fmt.Errorf("%d fields, expected %d", len(record), len(fields))}
+		}
+		values := make(map[string]interface{}, len(columns))
+		for i, c := range columns {
+			if c != nil {
+				values[c.Name] = record[i]
+			}
+		}
+		return line, record, values, nil
+	}
+	return src, nil
+}
+
+func (im *Importer) newJSONLSource(r io.Reader, rejects *rejectWriter) (*source, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxJSONLineBytes)
+	line := int64(0)
+
+	var columns []*utils.ColumnSchema
+	var fields []string
+	src := &source{schema: im.schema, rejects: rejects}
+	decode := func() (int64, []string, map[string]interface{}, error) {
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			raw := []string{string(text)}
+			decoder := json.NewDecoder(bytes.NewReader(text))
+			decoder.UseNumber()
+			var record map[string]interface{}
+			if err := decoder.Decode(&record); err != nil {
+				return line, raw, nil, &parseError{err: fmt.Errorf("invalid JSON: %w", err)}
+			}
+			if columns == nil {
+				return line, raw, record, nil
+			}
+
+			values := make(map[string]interface{}, len(record))
+			for field, value := range record {
+				i := indexOf(fields, field)
+				if i < 0 {
+					if _, ok := im.config.Mapping[field]; ok {
+						continue
+					}
+					return line, raw, nil, &parseError{err: fmt.Errorf("unknown field %s", field)}
+				}
+				if columns[i] != nil {
+					values[columns[i].Name] = value
+				}
+			}
+			return line, raw, values, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return line, nil, nil, err
+		}
+		return line, nil, nil, io.EOF
+	}
+
+	fields = im.config.Columns
+	var firstLine int64
+	var firstRaw []string
+	var firstRecord map[string]interface{}
+	if len(fields) == 0 {
+		// the columns are the keys of the first record
+		for {
+			l, raw, record, err := decode()
+			if err == io.EOF {
+				break
+			}
+			var pe *parseError
+			if errors.As(err, &pe) {
+				if err := src.reject(l, raw, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for field := range record {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			firstLine, firstRaw, firstRecord = l, raw, record
+			break
+		}
+	}
+	var err error
+	columns, err = im.resolveColumns(fields)
+	if err != nil {
+		return nil, err
+	}
+	src.fields = fields
+	src.tableColumns = columnNames(columns)
+
+	src.read = func() (int64, []string, map[string]interface{}, error) {
+		if firstRecord == nil {
+			return decode()
+		}
+		values := make(map[string]interface{}, len(firstRecord))
+		for i, field := range fields {
+			if columns[i] != nil {
+				values[columns[i].Name] = firstRecord[field]
+			}
+		}
+		firstRecord = nil
+		return firstLine, firstRaw, values, nil
+	}
+	return src, nil
+}
+
+func indexOf(values []string, s string) int {
+	for i, v := range values {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// rejectWriter write rejected records, CSV records are written as CSV with an extra _error field,
+// JSON Lines records as {"line":1,"error":"...","record":"..."}
+type rejectWriter struct {
+	w       io.Writer
+	format  Format
+	comma   rune
+	csv     *csv.Writer
+	started bool
+}
+
+func (w *rejectWriter) write(fields []string, line int64, raw []string, reason error) error {
+	if w.format == FormatJSONL {
+		record := ""
+		if len(raw) > 0 {
+			record = raw[0]
+		}
+		bz, err := json.Marshal(struct {
+			Line   int64  `json:"line"`
+			Error  string `json:"error"`
+			Record string `json:"record"`
+		}{line, reason.Error(), record})
+		if err != nil {
+			return err
+		}
+		_, err = w.w.Write(append(bz, '\n'))
+		return err
+	}
+
+	if w.csv == nil {
+		w.csv = csv.NewWriter(w.w)
+		w.csv.Comma = w.comma
+	}
+	if !w.started && len(fields) > 0 {
+		if err := w.csv.Write(append(append([]string{}, fields...), "_error")); err != nil {
+			return err
+		}
+	}
+	w.started = true
+	return w.csv.Write(append(append([]string{}, raw...), fmt.Sprintf("line %d: %s", line, reason)))
+}
+
+func (w *rejectWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/glitternetwork/glitter-sdk-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const ebookDDL = "CREATE TABLE `library`.`ebook` (\n" +
+	"  `_id` varchar(255) NOT NULL COMMENT 'md5',\n" +
+	"  `title` varchar(10) DEFAULT NULL COMMENT 'title, not null',\n" +
+	"  `filesize` int(11) unsigned DEFAULT NULL,\n" +
+	"  `rating` double,\n" +
+	"  `free` boolean,\n" +
+	"  `cover` blob,\n" +
+	"  PRIMARY KEY (`_id`),\n" +
+	"  FULLTEXT INDEX(title) WITH PARSER standard\n" +
+	") ENGINE=full_text COMMENT='book records'"
+
+func readAll(t *testing.T, src *source) [][]interface{} {
+	var rows [][]interface{}
+	for {
+		row, err := src.Next()
+		if err == io.EOF {
+			return rows
+		}
+		assert.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func Test_CSVSource(t *testing.T) {
+	schema, err := utils.ParseCreateTable(ebookDDL)
+	assert.NoError(t, err)
+	im, err := NewWithSchema(nil, schema, Config{Format: FormatCSV, Mapping: map[string]string{"md5": "_id", "note": ""}})
+	assert.NoError(t, err)
+
+	rejects := &bytes.Buffer{}
+	src, err := im.newCSVSource(strings.NewReader(`md5,title,filesize,rating,free,cover,note
+a1,Dune,1024,4.5,true,aGk=,ok
+a2,A very long title,1024,4.5,true,aGk=,too long
+a3,Emma,-1,4.5,true,aGk=,negative size
+a4,Walden,2048,,false,,empty rating
+a5,"Moby
+Dick",4096,3,0,,multiline
+`), &rejectWriter{w: rejects, format: FormatCSV, comma: ','})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"_id", "title", "filesize", "rating", "free", "cover"}, src.tableColumns)
+
+	rows := readAll(t, src)
+	assert.NoError(t, src.rejects.flush())
+	assert.Equal(t, [][]interface{}{
+		{"a1", "Dune", uint64(1024), 4.5, true, []byte("hi")},
+		{"a5", "Moby\nDick", uint64(4096), float64(3), false, []byte{}},
+	}, rows)
+	assert.Equal(t, int64(3), src.rejected)
+	assert.Equal(t, `md5,title,filesize,rating,free,cover,note,_error
+a2,A very long title,1024,4.5,true,aGk=,too long,line 3: column title: 17 characters exceeds VARCHAR(10)
+a3,Emma,-1,4.5,true,aGk=,negative size,"line 4: column filesize: invalid INT UNSIGNED ""-1"""
+a4,Walden,2048,,false,,empty rating,line 5: column rating: empty DOUBLE value
+`, rejects.String())
+}
+
+func Test_JSONLSource(t *testing.T) {
+	schema, err := utils.ParseCreateTable(ebookDDL)
+	assert.NoError(t, err)
+	im, err := NewWithSchema(nil, schema, Config{Format: FormatJSONL})
+	assert.NoError(t, err)
+
+	rejects := &bytes.Buffer{}
+	src, err := im.newJSONLSource(strings.NewReader(`{"_id":"a1","title":"Dune","filesize":1024}
+{"_id":"a2","title":"Emma"}
+not json
+
+{"_id":"a3","title":"Walden","filesize":2048,"isbn":"x"}
+{"_id":"a4","title":"Ulysses","filesize":"4096"}
+`), &rejectWriter{w: rejects, format: FormatJSONL})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"_id", "filesize", "title"}, src.tableColumns)
+
+	rows := readAll(t, src)
+	assert.Equal(t, [][]interface{}{
+		{"a1", uint64(1024), "Dune"},
+		{"a4", uint64(4096), "Ulysses"},
+	}, rows)
+	assert.Equal(t, int64(3), src.rejected)
+	lines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	assert.Equal(t, `{"line":2,"error":"column filesize: missing value","record":"{\"_id\":\"a2\",\"title\":\"Emma\"}"}`, lines[0])
+	assert.Contains(t, lines[1], `"line":3,"error":"invalid JSON`)
+	assert.Contains(t, lines[2], `"line":5,"error":"unknown field isbn"`)
+}
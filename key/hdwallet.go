@@ -0,0 +1,130 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/glitternetwork/glitter-sdk-go/msg"
+)
+
+// DefaultGapLimit number of consecutive unused addresses after which discovery stops
+const DefaultGapLimit = 20
+
+// AccountLoader loads on-chain account info, *client.LCDClient implements it
+type AccountLoader interface {
+	LoadAccount(ctx context.Context, address sdk.AccAddress) (authtypes.AccountI, error)
+}
+
+// HDAccount key derived from a mnemonic at HDPath
+type HDAccount struct {
+	Account uint32
+	Index   uint32
+	HDPath  string
+	PrivKey PrivKey
+}
+
+// Address returns the raw account address
+func (a *HDAccount) Address() sdk.AccAddress {
+	return sdk.AccAddress(a.PrivKey.PubKey().Address())
+}
+
+// Bech32Address returns the glitter1... form of the account address
+func (a *HDAccount) Bech32Address() string {
+	return msg.GlitterBech32Codec.AccAddressString(a.Address())
+}
+
+// EvmAddress returns the EIP-55 checksummed 0x... form of the account address
+func (a *HDAccount) EvmAddress() string {
+	return common.BytesToAddress(a.Address()).Hex()
+}
+
+// HDWallet derives accounts from a mnemonic and caches the derived keys
+type HDWallet struct {
+	mnemonic string
+
+	mu       sync.Mutex
+	accounts map[string]*HDAccount
+}
+
+// NewHDWallet create HD wallet from mnemonic
+func NewHDWallet(mnemonic string) (*HDWallet, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	return &HDWallet{
+		mnemonic: mnemonic,
+		accounts: make(map[string]*HDAccount),
+	}, nil
+}
+
+// Derive returns the key at CreateHDPath(account, index), deriving it on first use
+func (w *HDWallet) Derive(account uint32, index uint32) (*HDAccount, error) {
+	hdPath := CreateHDPath(account, index)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if acc, ok := w.accounts[hdPath]; ok {
+		return acc, nil
+	}
+
+	privKey, err := PrivKeyGenByMnemonic(w.mnemonic, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	acc := &HDAccount{
+		Account: account,
+		Index:   index,
+		HDPath:  hdPath,
+		PrivKey: privKey,
+	}
+	w.accounts[hdPath] = acc
+	return acc, nil
+}
+
+// DeriveRange derives count keys of account starting at index start
+func (w *HDWallet) DeriveRange(account uint32, start uint32, count uint32) ([]*HDAccount, error) {
+	accounts := make([]*HDAccount, 0, count)
+	for i := uint32(0); i < count; i++ {
+		acc, err := w.Derive(account, start+i)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+	return accounts, nil
+}
+
+// Discover walks the indexes of account and returns the keys that exist on chain.
+// It stops after gapLimit consecutive indexes are unknown to the chain,
+// DefaultGapLimit is used when gapLimit is 0.
+func (w *HDWallet) Discover(ctx context.Context, loader AccountLoader, account uint32, gapLimit uint32) ([]*HDAccount, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	var found []*HDAccount
+	for index, gap := uint32(0), uint32(0); gap < gapLimit; index++ {
+		acc, err := w.Derive(account, index)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = loader.LoadAccount(ctx, acc.Address())
+		switch {
+		case err == nil:
+			found = append(found, acc)
+			gap = 0
+		case errors.Is(err, sdkerrors.ErrUnknownAddress):
+			gap++
+		default:
+			return nil, sdkerrors.Wrapf(err, "failed to load account %s", acc.Bech32Address())
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,68 @@
+package key
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "lesson police usual earth embrace someone opera season urban produce jealous canyon shrug usage subject cigar imitate hollow route inhale vocal special sun fuel"
+
+type fakeLoader map[string]bool
+
+func (f fakeLoader) LoadAccount(_ context.Context, address sdk.AccAddress) (authtypes.AccountI, error) {
+	if !f[address.String()] {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownAddress, "not found")
+	}
+	return authtypes.NewBaseAccountWithAddress(address), nil
+}
+
+func Test_HDWalletDerive(t *testing.T) {
+	w, err := NewHDWallet(testMnemonic)
+	assert.NoError(t, err)
+
+	acc, err := w.Derive(0, 1)
+	assert.NoError(t, err)
+	privKey, err := PrivKeyGenByMnemonic(testMnemonic, CreateHDPath(0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, privKey.PubKey().Address().Bytes(), acc.Address().Bytes())
+	assert.Regexp(t, "^glitter1", acc.Bech32Address())
+	assert.Regexp(t, "^0x[0-9a-fA-F]{40}$", acc.EvmAddress())
+
+	cached, err := w.Derive(0, 1)
+	assert.NoError(t, err)
+	assert.Same(t, acc, cached)
+
+	accounts, err := w.DeriveRange(0, 0, 3)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 3)
+	assert.Same(t, acc, accounts[1])
+
+	_, err = NewHDWallet("invalid mnemonic")
+	assert.Error(t, err)
+}
+
+func Test_HDWalletDiscover(t *testing.T) {
+	w, err := NewHDWallet(testMnemonic)
+	assert.NoError(t, err)
+
+	used := fakeLoader{}
+	for _, index := range []uint32{0, 2, 5} {
+		acc, err := w.Derive(0, index)
+		assert.NoError(t, err)
+		used[acc.Address().String()] = true
+	}
+
+	found, err := w.Discover(context.Background(), used, 0, 3)
+	assert.NoError(t, err)
+	assert.Len(t, found, 3)
+	assert.Equal(t, uint32(5), found[2].Index)
+
+	found, err = w.Discover(context.Background(), used, 0, 2)
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+}
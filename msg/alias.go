@@ -14,6 +14,10 @@ type (
 
 	// MultiSend bank multi-send msg
 	MultiSend = banktypes.MsgMultiSend
+	// Input multi-send input
+	Input = banktypes.Input
+	// Output multi-send output
+	Output = banktypes.Output
 
 	// Coin nolint
 	Coin = sdk.Coin
@@ -29,7 +33,8 @@ type (
 	// Dec nolint
 	Dec = sdk.Dec
 
-	// AccAddress nolint
+	// AccAddress nolint, its String encodes by the global sdk.Config, cosmos1... unless the program sets
+	// the config, use GlitterBech32Codec.AccAddressString for the glitter1... form
 	AccAddress = sdk.AccAddress
 	// ValAddress nolint
 	ValAddress = sdk.ValAddress
@@ -41,6 +46,8 @@ type (
 var (
 	NewMsgSend      = banktypes.NewMsgSend
 	NewMsgMultiSend = banktypes.NewMsgMultiSend
+	NewInput        = banktypes.NewInput
+	NewOutput       = banktypes.NewOutput
 
 	NewCoin         = sdk.NewCoin
 	NewInt64Coin    = sdk.NewInt64Coin
@@ -63,10 +70,15 @@ var (
 	NewDecFromIntWithPrec    = sdk.NewDecFromIntWithPrec
 	NewDecFromStr            = sdk.NewDecFromStr
 	NewDecWithPrec           = sdk.NewDecWithPrec
-	AccAddressFromBech32     = sdk.AccAddressFromBech32
-	AccAddressFromHex        = sdk.AccAddressFromHex
-	ValAddressFromBech32     = sdk.ValAddressFromBech32
-	ValAddressFromHex        = sdk.ValAddressFromHex
-	ConsAddressFromBech32    = sdk.ConsAddressFromBech32
-	ConsAddressFromHex       = sdk.ConsAddressFromHex
+	// AccAddressFromBech32 decode a glitter1... account address, it does not read the global sdk.Config
+	AccAddressFromBech32 = GlitterBech32Codec.AccAddressFromBech32
+	AccAddressFromHex    = sdk.AccAddressFromHex
+	// Deprecated: ValAddressFromBech32 decodes by the prefix of the global sdk.Config, which the SDK no
+	// longer sets, call SetPrefixes of the tx package first if the program relies on it
+	ValAddressFromBech32 = sdk.ValAddressFromBech32
+	ValAddressFromHex    = sdk.ValAddressFromHex
+	// Deprecated: ConsAddressFromBech32 decodes by the prefix of the global sdk.Config, which the SDK no
+	// longer sets, call SetPrefixes of the tx package first if the program relies on it
+	ConsAddressFromBech32 = sdk.ConsAddressFromBech32
+	ConsAddressFromHex    = sdk.ConsAddressFromHex
 )
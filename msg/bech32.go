@@ -0,0 +1,61 @@
+package msg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// GlitterAccountPrefix bech32 prefix of glitter account addresses
+const GlitterAccountPrefix = "glitter"
+
+// maxAddrLen max length of an account address, the same as the default of sdk.VerifyAddressFormat
+const maxAddrLen = 255
+
+// Bech32Codec encodes and decodes the account addresses of a chain by its bech32 prefix,
+// unlike AccAddress.String and AccAddressFromBech32 it does not read the global sdk.Config,
+// so it works whatever other chain set the config and in any import order
+type Bech32Codec struct {
+	// AccountPrefix bech32 prefix of account addresses, such as glitter
+	AccountPrefix string
+}
+
+// GlitterBech32Codec codec of glitter account addresses
+var GlitterBech32Codec = NewBech32Codec(GlitterAccountPrefix)
+
+// NewBech32Codec create codec of the account address prefix
+func NewBech32Codec(accountPrefix string) Bech32Codec {
+	return Bech32Codec{AccountPrefix: accountPrefix}
+}
+
+// AccAddressString returns the bech32 form of addr, empty for an empty addr
+func (c Bech32Codec) AccAddressString(addr AccAddress) string {
+	if len(addr) == 0 {
+		return ""
+	}
+	s, err := bech32.ConvertAndEncode(c.AccountPrefix, addr)
+	if err != nil {
+		// only fails on an invalid prefix or oversized data
+		panic(err)
+	}
+	return s
+}
+
+// AccAddressFromBech32 decode the bech32 account address s, its prefix must be the codec prefix
+func (c Bech32Codec) AccAddressFromBech32(s string) (AccAddress, error) {
+	if len(s) == 0 {
+		return nil, errors.New("empty address string is not allowed")
+	}
+	hrp, bz, err := bech32.DecodeAndConvert(s)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != c.AccountPrefix {
+		return nil, fmt.Errorf("invalid Bech32 prefix; expected %s, got %s", c.AccountPrefix, hrp)
+	}
+	if len(bz) == 0 || len(bz) > maxAddrLen {
+		return nil, fmt.Errorf("invalid address length %d", len(bz))
+	}
+	return AccAddress(bz), nil
+}
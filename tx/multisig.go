@@ -0,0 +1,84 @@
+package tx
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+	"github.com/glitternetwork/glitter-sdk-go/key"
+)
+
+// MultisigPubKey legacy amino threshold multisig public key
+type MultisigPubKey = kmultisig.LegacyAminoPubKey
+
+func init() {
+	// glitter keys are eth_secp256k1, the multisig amino codec needs to know them
+	// to encode the multisig public key and derive its address.
+	kmultisig.AminoCdc.RegisterConcrete(&ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName, nil)
+}
+
+// NewMultisigPubKey - create threshold-of-len(pubKeys) multisig public key.
+// Keys are sorted by address the same way as `glitterd keys add --multisig`,
+// so the same members always produce the same multisig address.
+func NewMultisigPubKey(threshold int, pubKeys []cryptotypes.PubKey) (*MultisigPubKey, error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid threshold %d of %d keys", threshold, len(pubKeys))
+	}
+
+	sorted := make([]cryptotypes.PubKey, len(pubKeys))
+	copy(sorted, pubKeys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Address(), sorted[j].Address()) < 0
+	})
+	return kmultisig.NewLegacyAminoPubKey(threshold, sorted), nil
+}
+
+// SignMultisigPart - sign the tx as one member of a multisig account.
+// The tx signatures are left untouched, the returned member signature is
+// combined with the others by SetMultisigSignatures.
+// Multisig only supports SIGN_MODE_LEGACY_AMINO_JSON, signerData must hold the
+// account number and sequence of the multisig account.
+func (txBuilder Builder) SignMultisigPart(signerData SignerData, privKey key.PrivKey) (SignatureV2, error) {
+	return tx.SignWithPrivKey(
+		SignModeLegacyAminoJSON,
+		authsigning.SignerData(signerData),
+		client.TxBuilder(txBuilder.TxBuilder),
+		cryptotypes.PrivKey(privKey),
+		client.TxConfig(txBuilder.TxConfig),
+		signerData.Sequence,
+	)
+}
+
+// SetMultisigSignatures - verify the member signatures, merge them into a
+// MultiSignatureData and set it as the tx signature of multisigPubKey
+func (txBuilder Builder) SetMultisigSignatures(multisigPubKey *MultisigPubKey, signerData SignerData, sigs ...SignatureV2) error {
+	multisigSig := multisig.NewMultisig(len(multisigPubKey.PubKeys))
+	for _, sig := range sigs {
+		err := authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, txBuilder.SignModeHandler(), txBuilder.GetTx())
+		if err != nil {
+			return sdkerrors.Wrapf(err, "couldn't verify signature of %s", sig.PubKey.Address())
+		}
+
+		if err := multisig.AddSignatureV2(multisigSig, sig, multisigPubKey.GetPubKeys()); err != nil {
+			return err
+		}
+	}
+
+	if len(multisigSig.Signatures) < int(multisigPubKey.Threshold) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "got %d signatures, threshold is %d", len(multisigSig.Signatures), multisigPubKey.Threshold)
+	}
+
+	return txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigPubKey,
+		Data:     multisigSig,
+		Sequence: signerData.Sequence,
+	})
+}
@@ -1,44 +1,36 @@
 package tx
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	"github.com/glitternetwork/glitter-sdk-go/key"
 )
 
+// AccountAddressPrefix bech32 prefix of glitter account addresses
 const AccountAddressPrefix = "glitter"
 
-func init() {
-	//sdkConfig := sdk.GetConfig()
-	//sdkConfig.SetCoinType(118)
-	//sdkConfig.SetFullFundraiserPath(core.FullFundraiserPath)
-	//sdkConfig.SetBech32PrefixForAccount(core.Bech32PrefixAccAddr, core.Bech32PrefixAccPub)
-	//sdkConfig.SetBech32PrefixForValidator(core.Bech32PrefixValAddr, core.Bech32PrefixValPub)
-	//sdkConfig.SetBech32PrefixForConsensusNode(core.Bech32PrefixConsAddr, core.Bech32PrefixConsPub)
-	//sdkConfig.SetAddressVerifier(utils.AddressVerifier)
-	//sdkConfig.Seal()
-	SetPrefixes(AccountAddressPrefix)
-
-}
-
+// SetPrefixes set the bech32 prefixes of the global sdk.Config to the ones of accountAddressPrefix.
+// The SDK encodes addresses by explicit codecs and never calls it, it is only for applications
+// that use the cosmos-sdk address helpers relying on the global config, the config is not sealed
+// so that other chains of the process can still set it.
 func SetPrefixes(accountAddressPrefix string) {
-	// Set prefixes
 	accountPubKeyPrefix := accountAddressPrefix + "pub"
 	validatorAddressPrefix := accountAddressPrefix + "valoper"
 	validatorPubKeyPrefix := accountAddressPrefix + "valoperpub"
 	consNodeAddressPrefix := accountAddressPrefix + "valcons"
 	consNodePubKeyPrefix := accountAddressPrefix + "valconspub"
 
-	// Set and seal config
 	config := sdk.GetConfig()
 	config.SetBech32PrefixForAccount(accountAddressPrefix, accountPubKeyPrefix)
 	config.SetBech32PrefixForValidator(validatorAddressPrefix, validatorPubKeyPrefix)
 	config.SetBech32PrefixForConsensusNode(consNodeAddressPrefix, consNodePubKeyPrefix)
-	config.Seal()
 }
 
 // NewTxBuilder - create TxBuilder
@@ -106,7 +98,45 @@ func (txBuilder Builder) Sign(
 	return txBuilder.SetSignatures(prevSignatures...)
 }
 
+// SetFeeGranterBech32 set the bech32 address of the fee granter as is, unlike SetFeeGranter
+// it does not encode the address by the global sdk.Config. Only the protobuf tx builders hold
+// the encoded granter, the others return an error for a non-empty granter
+func (txBuilder Builder) SetFeeGranterBech32(granter string) error {
+	// reset the fee and its cached auth info bytes, then overwrite the granter
+	txBuilder.SetFeeGranter(nil)
+	if len(granter) == 0 {
+		return nil
+	}
+	p, ok := txBuilder.TxBuilder.(interface{ GetProtoTx() *sdktx.Tx })
+	if !ok {
+		return fmt.Errorf("tx builder %T does not support setting the fee granter %s", txBuilder.TxBuilder, granter)
+	}
+	p.GetProtoTx().AuthInfo.Fee.Granter = granter
+	return nil
+}
+
 // GetTxBytes return tx bytes for broadcast
 func (txBuilder Builder) GetTxBytes() ([]byte, error) {
 	return txBuilder.TxConfig.TxEncoder()(txBuilder.GetTx())
 }
+
+// GetTxJSON return tx json in the format of `glitterd tx sign --generate-only`
+func (txBuilder Builder) GetTxJSON() ([]byte, error) {
+	return txBuilder.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// DecodeTxJSON - restore TxBuilder from tx json
+func DecodeTxJSON(txConfig client.TxConfig, bz []byte) (Builder, error) {
+	decoded, err := txConfig.TxJSONDecoder()(bz)
+	if err != nil {
+		return Builder{}, err
+	}
+	txBuilder, err := txConfig.WrapTxBuilder(decoded)
+	if err != nil {
+		return Builder{}, err
+	}
+	return Builder{
+		TxBuilder: txBuilder,
+		TxConfig:  txConfig,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SQLFingerprint normalize sql so statements of the same shape share a fingerprint,
+// literals are replaced by ?, whitespace is collapsed and keywords are lower cased
+func SQLFingerprint(sql string) string {
+	b := strings.Builder{}
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"':
+			i = skipQuoted(sql, i)
+			c = '?'
+		case c == '`':
+			end := skipQuoted(sql, i)
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteString(sql[i : end+1])
+			i = end
+			continue
+		case isDigit(c) && (i == 0 || !isIdentChar(sql[i-1])):
+			for i+1 < len(sql) && (isDigit(sql[i+1]) || sql[i+1] == '.') {
+				i++
+			}
+			c = '?'
+		case unicode.IsSpace(rune(c)):
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte(toLower(c))
+	}
+	return b.String()
+}
+
+// StatementKind returns the upper cased leading keyword of sql, such as INSERT or CREATE
+func StatementKind(sql string) string {
+	sql = strings.TrimLeftFunc(sql, unicode.IsSpace)
+	end := strings.IndexFunc(sql, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		end = len(sql)
+	}
+	return strings.ToUpper(sql[:end])
+}
+
+// writeKinds kinds of the statements writing a database or table
+var writeKinds = map[string]bool{
+	"INSERT": true, "REPLACE": true, "UPDATE": true, "DELETE": true, "CREATE": true,
+	"DROP": true, "ALTER": true, "TRUNCATE": true, "RENAME": true,
+}
+
+// targetSkipWords modifiers and keywords between the statement kind and its target name
+var targetSkipWords = map[string]bool{
+	"INTO": true, "FROM": true, "TABLE": true, "IGNORE": true, "LOW_PRIORITY": true, "DELAYED": true,
+	"HIGH_PRIORITY": true, "QUICK": true, "IF": true, "NOT": true, "EXISTS": true, "TEMPORARY": true,
+	"UNIQUE": true, "FULLTEXT": true, "SPATIAL": true,
+}
+
+// StatementTarget returns the database and the table written by sql, such as the table of
+// INSERT INTO db.t or CREATE INDEX idx ON db.t, the table is empty for CREATE and DROP DATABASE
+// and the database is empty if the table is not qualified, both are empty if sql does not write
+func StatementTarget(sql string) (database, table string) {
+	kind := StatementKind(sql)
+	if !writeKinds[kind] {
+		return "", ""
+	}
+	rest := strings.TrimLeftFunc(sql, unicode.IsSpace)[len(kind):]
+	onDatabase := false
+	for {
+		name, next := nextName(rest)
+		if len(name) == 0 {
+			return "", ""
+		}
+		upper := strings.ToUpper(name)
+		switch {
+		case upper == "DATABASE" || upper == "SCHEMA":
+			onDatabase = true
+		case upper == "INDEX":
+			// the table of CREATE and DROP INDEX follows ON, after the name of the index
+			for !strings.EqualFold(name, "ON") {
+				if name, next = nextName(next); len(name) == 0 {
+					return "", ""
+				}
+			}
+		case !targetSkipWords[upper] || name[0] == '`':
+			parts := splitQualifiedName(name)
+			if onDatabase {
+				return parts[0], ""
+			}
+			if len(parts) == 1 {
+				return "", parts[0]
+			}
+			return parts[0], parts[1]
+		}
+		rest = next
+	}
+}
+
+// nextName returns the next identifier of s, possibly qualified and back quoted such as `db`.t,
+// and the rest of s after it
+func nextName(s string) (string, string) {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '`':
+			i = skipQuoted(s, i) + 1
+		case isIdentChar(c) || c == '.' || c == '$':
+			i++
+		default:
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// splitQualifiedName split db.table into its unquoted parts
+func splitQualifiedName(name string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '`':
+			i = skipQuoted(name, i)
+		case '.':
+			parts = append(parts, unquoteName(name[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, unquoteName(name[start:]))
+}
+
+func unquoteName(s string) string {
+	if len(s) >= 2 && s[0] == '`' && s[len(s)-1] == '`' {
+		return strings.ReplaceAll(s[1:len(s)-1], "``", "`")
+	}
+	return s
+}
+
+// SplitInsertValues split an INSERT ... VALUES statement into the statement before VALUES
+// and the number of value rows, ok is false if sql is not such a statement
+func SplitInsertValues(sql string) (head string, rows int, ok bool) {
+	if StatementKind(sql) != "INSERT" {
+		return "", 0, false
+	}
+	valuesAt := -1
+	depth := 0
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(sql, i)
+		case c == '(':
+			if valuesAt >= 0 && depth == 0 {
+				rows++
+			}
+			depth++
+		case c == ')':
+			depth--
+		case valuesAt < 0 && depth == 0 && (c == 'v' || c == 'V') && (i == 0 || !isIdentChar(sql[i-1])) &&
+			strings.EqualFold(sql[i:min(i+6, len(sql))], "values") && (i+6 == len(sql) || !isIdentChar(sql[i+6])):
+			valuesAt = i
+			i += 5
+		}
+	}
+	if valuesAt < 0 || rows == 0 {
+		return "", 0, false
+	}
+	return strings.TrimRightFunc(sql[:valuesAt], unicode.IsSpace), rows, true
+}
+
+// skipQuoted returns the index of the quote closing the quoted string starting at i
+func skipQuoted(s string, i int) int {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			if j+1 < len(s) && s[j+1] == quote {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return len(s) - 1
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func toLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SQLFingerprint(t *testing.T) {
+	assert.Equal(t, "insert into `db`.`Book` (id, title) values (?, ?)",
+		SQLFingerprint("INSERT INTO `db`.`Book`  (id, title)\n VALUES (12, 'it''s')"))
+
+	head, rows, ok := SplitInsertValues("insert into db.book (id,title) values (?,?),(?,'a,(b)'),(?,?)")
+	assert.True(t, ok)
+	assert.Equal(t, "insert into db.book (id,title)", head)
+	assert.Equal(t, 3, rows)
+	_, _, ok = SplitInsertValues("update db.book set title='values (1)'")
+	assert.False(t, ok)
+}
+
+func Test_StatementTarget(t *testing.T) {
+	tests := []struct {
+		sql      string
+		database string
+		table    string
+	}{
+		{"insert into library.ebook values (1)", "library", "ebook"},
+		{"INSERT IGNORE INTO `library`.`ebook` VALUES (1)", "library", "ebook"},
+		{"replace into ebook values (1)", "", "ebook"},
+		{"  update library.ebook set id=2", "library", "ebook"},
+		{"delete from `library`.ebook where id=2", "library", "ebook"},
+		{"create table if not exists library.ebook (id int)", "library", "ebook"},
+		{"drop table `library`.`ebook`", "library", "ebook"},
+		{"truncate table library.ebook", "library", "ebook"},
+		{"alter table library.ebook add index idx_id (id)", "library", "ebook"},
+		{"create database if not exists library", "library", ""},
+		{"DROP SCHEMA `library`", "library", ""},
+		{"create index idx_id on library.ebook (id)", "library", "ebook"},
+		{"CREATE UNIQUE INDEX idx_id ON `library`.`ebook`(id)", "library", "ebook"},
+		{"create fulltext index idx_title using btree on ebook (title)", "", "ebook"},
+		{"drop index idx_id on library.ebook", "library", "ebook"},
+		{"create index idx_id", "", ""},
+		{"select * from library.ebook", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		database, table := StatementTarget(tt.sql)
+		assert.Equal(t, tt.database, database, tt.sql)
+		assert.Equal(t, tt.table, table, tt.sql)
+	}
+}
@@ -41,8 +41,8 @@ func TestDateRangeQuery(t *testing.T) {
 }
 
 func TestHighlight(t *testing.T) {
-	m1, err := HighlightHint([]string{"author", "title"})
-	fmt.Printf("m1=%s, err=%+v\n", m1, err)
+	m1 := HighlightHint([]string{"author", "title"})
+	fmt.Printf("m1=%s\n", m1)
 }
 
 func TestCandyQueryString(t *testing.T) {
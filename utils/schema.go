@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	glittertypes "github.com/glitternetwork/glitter.proto/golang/glitter_proto/index/types"
+)
+
+// TableSchema table definition parsed from a CREATE TABLE statement
+type TableSchema struct {
+	Database string
+	Table    string
+	Engine   string
+	Comment  string
+	Columns  []ColumnSchema
+}
+
+// ColumnSchema column definition of a TableSchema
+type ColumnSchema struct {
+	Name string
+	// Type upper cased sql type without length, such as VARCHAR or INT
+	Type string
+	// Length declared length, such as 255 of VARCHAR(255), 0 if not declared
+	Length     int
+	Unsigned   bool
+	NotNull    bool
+	PrimaryKey bool
+	HasDefault bool
+	Comment    string
+}
+
+var (
+	createTableRegexp  = regexp.MustCompile("(?is)^\\s*CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?([`\\w.]+)\\s*\\(")
+	engineRegexp       = regexp.MustCompile(`(?i)ENGINE\s*=\s*(\w+)`)
+	commentRegexp      = regexp.MustCompile(`(?i)COMMENT\s*=?\s*'((?:[^'\\]|\\.|'')*)'`)
+	columnTypeRegexp   = regexp.MustCompile(`^(?i)([a-z]+)\s*(?:\(\s*(\d+)[^)]*\))?`)
+	tableConstraintKey = []string{"KEY", "INDEX", "UNIQUE", "FULLTEXT", "SPATIAL", "CONSTRAINT", "FOREIGN", "CHECK"}
+)
+
+// ParseCreateTable parse the columns, engine and comment of a CREATE TABLE statement,
+// such as the schema returned by ShowCreateTable
+func ParseCreateTable(ddl string) (*TableSchema, error) {
+	m := createTableRegexp.FindStringSubmatchIndex(ddl)
+	if m == nil {
+		return nil, fmt.Errorf("not a CREATE TABLE statement")
+	}
+	schema := &TableSchema{}
+	name := strings.ReplaceAll(ddl[m[2]:m[3]], "`", "")
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		schema.Database, schema.Table = name[:i], name[i+1:]
+	} else {
+		schema.Table = name
+	}
+
+	open := m[1] - 1
+	end := matchParen(ddl, open)
+	if end < 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in CREATE TABLE statement")
+	}
+	if em := engineRegexp.FindStringSubmatch(ddl[end:]); em != nil {
+		schema.Engine = em[1]
+	}
+	if cm := commentRegexp.FindStringSubmatch(ddl[end:]); cm != nil {
+		schema.Comment = unquoteSQL(cm[1])
+	}
+
+	var primaryKeys []string
+	for _, def := range splitTopLevel(ddl[open+1 : end]) {
+		def = strings.TrimSpace(def)
+		if len(def) == 0 {
+			continue
+		}
+		keyword := strings.ToUpper(StatementKind(def))
+		if keyword == "PRIMARY" {
+			primaryKeys = append(primaryKeys, parseKeyColumns(def)...)
+			continue
+		}
+		if ContainsString(tableConstraintKey, keyword) {
+			continue
+		}
+		column, err := parseColumnDef(def)
+		if err != nil {
+			return nil, err
+		}
+		schema.Columns = append(schema.Columns, column)
+	}
+	for _, pk := range primaryKeys {
+		for i := range schema.Columns {
+			if schema.Columns[i].Name == pk {
+				schema.Columns[i].PrimaryKey = true
+			}
+		}
+	}
+	return schema, nil
+}
+
+// Column returns the column of name, nil if the table has no such column
+func (s *TableSchema) Column(name string) *ColumnSchema {
+	for i := range s.Columns {
+		if strings.EqualFold(s.Columns[i].Name, name) {
+			return &s.Columns[i]
+		}
+	}
+	return nil
+}
+
+// ValueType returns the glitter value type of the column
+func (c *ColumnSchema) ValueType() glittertypes.ColumnValueType {
+	switch c.Type {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT":
+		if c.Unsigned {
+			return glittertypes.ColumnValueType_UintColumn
+		}
+		return glittertypes.ColumnValueType_IntColumn
+	case "FLOAT", "DOUBLE", "REAL", "DECIMAL", "NUMERIC":
+		return glittertypes.ColumnValueType_FloatColumn
+	case "BOOL", "BOOLEAN":
+		return glittertypes.ColumnValueType_BoolColumn
+	case "BINARY", "VARBINARY", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB":
+		return glittertypes.ColumnValueType_BytesColumn
+	default:
+		return glittertypes.ColumnValueType_StringColumn
+	}
+}
+
+// parseColumnDef parse a column definition such as "title VARCHAR(255) NOT NULL COMMENT 'title'"
+func parseColumnDef(def string) (ColumnSchema, error) {
+	var column ColumnSchema
+	rest := def
+	if strings.HasPrefix(rest, "`") {
+		end := strings.IndexByte(rest[1:], '`')
+		if end < 0 {
+			return column, fmt.Errorf("invalid column definition: %s", def)
+		}
+		column.Name, rest = rest[1:end+1], rest[end+2:]
+	} else {
+		fields := strings.Fields(rest)
+		column.Name = fields[0]
+		rest = rest[len(fields[0]):]
+	}
+	rest = strings.TrimSpace(rest)
+
+	tm := columnTypeRegexp.FindStringSubmatch(rest)
+	if tm == nil {
+		return column, fmt.Errorf("invalid type of column %s: %s", column.Name, def)
+	}
+	column.Type = strings.ToUpper(tm[1])
+	if len(tm[2]) > 0 {
+		column.Length, _ = strconv.Atoi(tm[2])
+	}
+
+	options := strings.ToUpper(commentRegexp.ReplaceAllString(rest[len(tm[0]):], ""))
+	column.Unsigned = strings.Contains(options, "UNSIGNED")
+	column.NotNull = strings.Contains(options, "NOT NULL")
+	column.PrimaryKey = strings.Contains(options, "PRIMARY KEY")
+	column.HasDefault = strings.Contains(options, "DEFAULT") || strings.Contains(options, "AUTO_INCREMENT")
+	if cm := commentRegexp.FindStringSubmatch(rest); cm != nil {
+		column.Comment = unquoteSQL(cm[1])
+	}
+	return column, nil
+}
+
+// parseKeyColumns returns the columns of a key definition such as "PRIMARY KEY (`a`, `b`)"
+func parseKeyColumns(def string) []string {
+	start := strings.IndexByte(def, '(')
+	if start < 0 {
+		return nil
+	}
+	end := matchParen(def, start)
+	if end < 0 {
+		return nil
+	}
+	var columns []string
+	for _, c := range splitTopLevel(def[start+1 : end]) {
+		c = strings.Trim(strings.TrimSpace(c), "`")
+		if i := strings.IndexByte(c, '('); i >= 0 {
+			c = c[:i]
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// matchParen returns the index of the parenthesis closing the one at open, -1 if unbalanced
+func matchParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipQuoted(s, i)
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel split s by the commas outside parentheses and quotes
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipQuoted(s, i)
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unquoteSQL(s string) string {
+	return strings.NewReplacer("''", "'", `\'`, "'", `\\`, `\`).Replace(s)
+}
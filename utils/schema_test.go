@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ebookDDL = "CREATE TABLE `library`.`ebook` (\n" +
+	"  `_id` varchar(255) NOT NULL COMMENT 'md5',\n" +
+	"  `title` varchar(10) DEFAULT NULL COMMENT 'title, not null',\n" +
+	"  `filesize` int(11) unsigned DEFAULT NULL,\n" +
+	"  `rating` double,\n" +
+	"  `free` boolean,\n" +
+	"  `cover` blob,\n" +
+	"  PRIMARY KEY (`_id`),\n" +
+	"  FULLTEXT INDEX(title) WITH PARSER standard\n" +
+	") ENGINE=full_text COMMENT='book records'"
+
+func Test_ParseCreateTable(t *testing.T) {
+	schema, err := ParseCreateTable(ebookDDL)
+	assert.NoError(t, err)
+	assert.Equal(t, "library", schema.Database)
+	assert.Equal(t, "ebook", schema.Table)
+	assert.Equal(t, "full_text", schema.Engine)
+	assert.Equal(t, "book records", schema.Comment)
+	assert.Len(t, schema.Columns, 6)
+	assert.Equal(t, ColumnSchema{Name: "_id", Type: "VARCHAR", Length: 255, NotNull: true, PrimaryKey: true, Comment: "md5"}, schema.Columns[0])
+	assert.False(t, schema.Column("title").NotNull)
+	assert.True(t, schema.Column("filesize").Unsigned)
+}
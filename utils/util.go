@@ -10,6 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethermint "github.com/evmos/ethermint/types"
+	"github.com/glitternetwork/glitter-sdk-go/address"
 )
 
 func toGlitterArgument(columnValue interface{}) (*glittertypes.Argument, error) {
@@ -151,43 +152,27 @@ func BuildDeleteStatement(table string, whereEqual map[string]interface{}, order
 	return updateSql, args, nil
 }
 
+// GetEvmAddrFromGlitterAddr convert the glitter1... address to its EIP-55 0x... form
+//
+// Deprecated: use address.Parse and Address.Hex
 func GetEvmAddrFromGlitterAddr(glitterAddr string) (string, error) {
-	accountPubKeyPrefix := AccountAddressPrefix + "pub"
-	validatorAddressPrefix := AccountAddressPrefix + "valoper"
-	validatorPubKeyPrefix := AccountAddressPrefix + "valoperpub"
-	consNodeAddressPrefix := AccountAddressPrefix + "valcons"
-	consNodePubKeyPrefix := AccountAddressPrefix + "valconspub"
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount(AccountAddressPrefix, accountPubKeyPrefix)
-	config.SetBech32PrefixForValidator(validatorAddressPrefix, validatorPubKeyPrefix)
-	config.SetBech32PrefixForConsensusNode(consNodeAddressPrefix, consNodePubKeyPrefix)
-	SetBip44CoinType(config)
-	accAddr, err := sdk.AccAddressFromBech32(glitterAddr)
+	a, err := address.Parse(glitterAddr)
 	if err != nil {
 		return "", err
 	}
-	evmAddr := common.BytesToAddress(accAddr).String()
-	return evmAddr, nil
+	return a.Hex(), nil
 }
 
+// GetGlitterAddrFromEvmAddr convert the 0x... address to its glitter1... form, unlike address.Parse
+// it does not validate the EIP-55 checksum
+//
+// Deprecated: use address.Parse and Address.String
 func GetGlitterAddrFromEvmAddr(evmAddr string) (string, error) {
-	accountPubKeyPrefix := AccountAddressPrefix + "pub"
-	validatorAddressPrefix := AccountAddressPrefix + "valoper"
-	validatorPubKeyPrefix := AccountAddressPrefix + "valoperpub"
-	consNodeAddressPrefix := AccountAddressPrefix + "valcons"
-	consNodePubKeyPrefix := AccountAddressPrefix + "valconspub"
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount(AccountAddressPrefix, accountPubKeyPrefix)
-	config.SetBech32PrefixForValidator(validatorAddressPrefix, validatorPubKeyPrefix)
-	config.SetBech32PrefixForConsensusNode(consNodeAddressPrefix, consNodePubKeyPrefix)
-	SetBip44CoinType(config)
-
-	glitterAddrStrFromEth := sdk.AccAddress(common.HexToAddress(evmAddr).Bytes()).String()
-	accAddr, err := sdk.AccAddressFromBech32(glitterAddrStrFromEth)
+	a, err := address.FromBytes(common.HexToAddress(evmAddr).Bytes())
 	if err != nil {
 		return "", err
 	}
-	return accAddr.String(), nil
+	return a.String(), nil
 }
 
 func SetBip44CoinType(config *sdk.Config) {
@@ -199,3 +184,23 @@ func SetBip44CoinType(config *sdk.Config) {
 func FullTableName(db, table string) string {
 	return fmt.Sprintf("%s.%s", db, table)
 }
+
+// ContainsString returns whether values contains s
+func ContainsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstNonEmpty returns the first non empty value, empty if all are empty
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}